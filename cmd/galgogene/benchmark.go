@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sbiemont/galgogene/dashboard"
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+)
+
+// benchmarkFunction is a standard function to be minimized on a given domain (its minimum is 0)
+type benchmarkFunction struct {
+	min  float64 // Min value of each variable
+	max  float64 // Max value of each variable
+	eval func(x []float64) float64
+}
+
+// https://en.wikipedia.org/wiki/Test_functions_for_optimization
+var benchmarkFunctions = map[string]benchmarkFunction{
+	"sphere": {
+		min: -5.12,
+		max: 5.12,
+		eval: func(x []float64) float64 {
+			var res float64
+			for _, xi := range x {
+				res += xi * xi
+			}
+			return res
+		},
+	},
+	"rastrigin": {
+		min: -5.12,
+		max: 5.12,
+		eval: func(x []float64) float64 {
+			res := 10 * float64(len(x))
+			for _, xi := range x {
+				res += xi*xi - 10*math.Cos(2*math.Pi*xi)
+			}
+			return res
+		},
+	},
+	"rosenbrock": {
+		min: -2.048,
+		max: 2.048,
+		eval: func(x []float64) float64 {
+			var res float64
+			for i := range len(x) - 1 {
+				res += 100*math.Pow(x[i+1]-x[i]*x[i], 2) + math.Pow(1-x[i], 2)
+			}
+			return res
+		},
+	},
+	"ackley": {
+		min: -32.768,
+		max: 32.768,
+		eval: func(x []float64) float64 {
+			var sumSq, sumCos float64
+			for _, xi := range x {
+				sumSq += xi * xi
+				sumCos += math.Cos(2 * math.Pi * xi)
+			}
+			n := float64(len(x))
+			return -20*math.Exp(-0.2*math.Sqrt(sumSq/n)) - math.Exp(sumCos/n) + 20 + math.E
+		},
+	},
+}
+
+// benchmark minimizes a benchmark function
+// Each variable is encoded using a fixed number of bits (1 bit per base)
+type benchmark struct {
+	fct    benchmarkFunction
+	schema gene.Schema
+}
+
+func newBenchmark(cfg BenchmarkConfig) (benchmark, error) {
+	fct, ok := benchmarkFunctions[cfg.Function]
+	switch {
+	case !ok:
+		return benchmark{}, fmt.Errorf("benchmark: unknown function %q", cfg.Function)
+	case cfg.Dimensions <= 0:
+		return benchmark{}, errors.New("benchmark: dimensions shall be > 0")
+	}
+
+	coding := gene.BinaryCoding
+	if cfg.Gray {
+		coding = gene.GrayCoding
+	}
+	params := make([]gene.Param, cfg.Dimensions)
+	for i := range params {
+		params[i] = gene.Param{Name: fmt.Sprintf("x%d", i+1), Min: fct.min, Max: fct.max, Bits: cfg.Bits}
+	}
+	schema, err := gene.NewSchema(coding, params...)
+	if err != nil {
+		return benchmark{}, fmt.Errorf("benchmark: %w", err)
+	}
+	return benchmark{
+		fct:    fct,
+		schema: schema,
+	}, nil
+}
+
+func (bm benchmark) size() int {
+	return bm.schema.Size()
+}
+
+func (bm benchmark) engine() engine.Engine {
+	return engine.Engine{
+		Initializer: gene.NewRandomInitializer(1),
+		Selection:   operator.TournamentSelection{Fighters: 4},
+		CrossOver:   operator.TwoPointsCrossOver{},
+		Mutation: operator.MultiMutation{ApplyAll: true}.
+			Use(0.5, operator.UniqueMutation{}).
+			Use(0.1, operator.UniqueMutation{}),
+		Survivor: operator.EliteSurvivor{},
+		Fitness:  bm.fitness,
+	}
+}
+
+// decode converts each group of bits into a variable in [min ; max]
+func (bm benchmark) decode(chrm gene.Chromosome) []float64 {
+	return bm.schema.Decode(chrm)
+}
+
+// fitness: the fitness increases when the function result decreases to 0
+func (bm benchmark) fitness(chrm gene.Chromosome) float64 {
+	return 1 / (1 + bm.fct.eval(bm.decode(chrm)))
+}
+
+func (bm benchmark) optimum() float64 {
+	return 0
+}
+
+func (bm benchmark) describe(chrm gene.Chromosome) string {
+	x := bm.decode(chrm)
+	values := make([]string, len(x))
+	for i, xi := range x {
+		values[i] = fmt.Sprintf("%.6f", xi)
+	}
+	return fmt.Sprintf("f(x): %.6f, x: [%s]", bm.fct.eval(x), strings.Join(values, ", "))
+}
+
+func (benchmark) renderer() dashboard.Renderer {
+	return nil
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/sbiemont/galgogene/operator"
+)
+
+// Config gathers all parameters of a run
+// It can be loaded from a json file, each parameter can be overridden using command-line flags
+type Config struct {
+	Problem     string          `json:"problem"`     // Name of the problem to be solved (string, tsp, knapsack, benchmark)
+	Seed        uint64          `json:"seed"`        // Random seed (0: randomly chosen)
+	Population  int             `json:"population"`  // Number of individuals in a population
+	Offspring   int             `json:"offspring"`   // Number of individuals in the offspring population
+	Generations int             `json:"generations"` // Max generation to be reached (0: no limit)
+	Duration    Duration        `json:"duration"`    // Max duration (0: no limit)
+	Fitness     float64         `json:"fitness"`     // Min fitness to be reached (0: use the problem optimum if known)
+	Improvement int             `json:"improvement"` // Number of generations without improvement (0: no limit)
+	Every       int             `json:"every"`       // Print the progress every n generations
+	Output      string          `json:"output"`      // Output file for the best solution (json)
+	History     string          `json:"history"`     // Output file for the history of all generations (csv)
+	Resume      string          `json:"resume"`      // Output file of a previous run (json) whose solution seeds the initial population
+	HTTP        string          `json:"http"`        // Listening address of the live dashboard and metrics (eg.: ":8080")
+	Log         string          `json:"log"`         // Structured logs format on stderr instead of the progress (text, json)
+	String      StringConfig    `json:"string"`
+	TSP         TSPConfig       `json:"tsp"`
+	Knapsack    KnapsackConfig  `json:"knapsack"`
+	Benchmark   BenchmarkConfig `json:"benchmark"`
+}
+
+// StringConfig defines the string matcher problem
+type StringConfig struct {
+	Target string `json:"target"` // String to be found
+}
+
+// TSPConfig defines the traveling salesman problem
+type TSPConfig struct {
+	File string `json:"file"` // CSV file of cities coordinates (x,y)
+}
+
+// KnapsackConfig defines the 0-1 knapsack problem
+type KnapsackConfig struct {
+	File     string  `json:"file"`     // CSV file of items (weight,value) ; if not set, random items are generated
+	Items    int     `json:"items"`    // Number of random items to be generated
+	Capacity float64 `json:"capacity"` // Max weight of the knapsack (0: half of the total weight)
+}
+
+// BenchmarkConfig defines the minimization of a benchmark function
+type BenchmarkConfig struct {
+	Function   string `json:"function"`   // Name of the function (sphere, rastrigin, rosenbrock, ackley)
+	Dimensions int    `json:"dimensions"` // Number of variables
+	Bits       int    `json:"bits"`       // Number of bits used to encode each variable
+	Gray       bool   `json:"gray"`       // Use the Gray coding instead of the plain binary coding
+}
+
+// Duration is a json and flag compatible duration (eg.: "1m30s")
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	return d.Set(str)
+}
+
+func (d *Duration) Set(str string) error {
+	dur, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	d.Duration = dur
+	return nil
+}
+
+// defaultConfig returns the parameters used when nothing is specified
+func defaultConfig() Config {
+	return Config{
+		Problem:     "string",
+		Population:  100,
+		Generations: 1000,
+		Every:       1,
+		String: StringConfig{
+			Target: "Hello, World!",
+		},
+		Knapsack: KnapsackConfig{
+			Items: 50,
+		},
+		Benchmark: BenchmarkConfig{
+			Function:   "rastrigin",
+			Dimensions: 2,
+			Bits:       16,
+		},
+	}
+}
+
+// loadConfig reads a json configuration file on top of the default configuration
+func loadConfig(filename string) (Config, error) {
+	cfg := defaultConfig()
+	f, err := os.Open(filename)
+	if err != nil {
+		return Config{}, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// bindFlags links all command-line flags to the configuration
+func bindFlags(fs *flag.FlagSet, cfg *Config) {
+	fs.StringVar(&cfg.Problem, "problem", cfg.Problem, "problem to be solved: string, tsp, knapsack, benchmark")
+	fs.Uint64Var(&cfg.Seed, "seed", cfg.Seed, "random seed, the same seed gives the same run if not stopped by -duration (0: randomly chosen)")
+	fs.IntVar(&cfg.Population, "pop", cfg.Population, "population size")
+	fs.IntVar(&cfg.Offspring, "offspring", cfg.Offspring, "offspring population size (0: same as population)")
+	fs.IntVar(&cfg.Generations, "generations", cfg.Generations, "max generation to be reached (0: no limit)")
+	fs.Var(&cfg.Duration, "duration", "max duration, eg.: 30s (0: no limit)")
+	fs.Float64Var(&cfg.Fitness, "fitness", cfg.Fitness, "min fitness to be reached (0: problem optimum if known)")
+	fs.IntVar(&cfg.Improvement, "improvement", cfg.Improvement, "max generations without improvement (0: no limit)")
+	fs.IntVar(&cfg.Every, "every", cfg.Every, "print the progress every n generations")
+	fs.StringVar(&cfg.Output, "output", cfg.Output, "output json file for the best solution")
+	fs.StringVar(&cfg.History, "history", cfg.History, "output csv file for the history of all generations")
+	fs.StringVar(&cfg.Resume, "resume", cfg.Resume, "output json file of a previous run, its solution seeds the initial population")
+	fs.StringVar(&cfg.HTTP, "http", cfg.HTTP, "listening address of the live dashboard (/) and prometheus metrics (/metrics), eg.: :8080")
+	fs.StringVar(&cfg.Log, "log", cfg.Log, "write structured logs on stderr instead of the progress: text, json")
+	fs.StringVar(&cfg.String.Target, "string.target", cfg.String.Target, "string matcher: string to be found")
+	fs.StringVar(&cfg.TSP.File, "tsp.file", cfg.TSP.File, "tsp: csv file of cities coordinates (x,y)")
+	fs.StringVar(&cfg.Knapsack.File, "knapsack.file", cfg.Knapsack.File, "knapsack: csv file of items (weight,value)")
+	fs.IntVar(&cfg.Knapsack.Items, "knapsack.items", cfg.Knapsack.Items, "knapsack: number of random items (without file)")
+	fs.Float64Var(&cfg.Knapsack.Capacity, "knapsack.capacity", cfg.Knapsack.Capacity, "knapsack: max weight (0: half of the total weight)")
+	fs.StringVar(&cfg.Benchmark.Function, "benchmark.function", cfg.Benchmark.Function, "benchmark: sphere, rastrigin, rosenbrock, ackley")
+	fs.IntVar(&cfg.Benchmark.Dimensions, "benchmark.dimensions", cfg.Benchmark.Dimensions, "benchmark: number of variables")
+	fs.IntVar(&cfg.Benchmark.Bits, "benchmark.bits", cfg.Benchmark.Bits, "benchmark: number of bits per variable")
+	fs.BoolVar(&cfg.Benchmark.Gray, "benchmark.gray", cfg.Benchmark.Gray, "benchmark: use the gray coding of variables")
+}
+
+// parseArgs builds the configuration using (by priority order):
+// * command-line flags
+// * json configuration file (flag -config)
+// * default configuration
+func parseArgs(args []string) (Config, error) {
+	// Parse the command-line a first time to find the explicitly set flags
+	cfg := defaultConfig()
+	fs := flag.NewFlagSet("galgogene", flag.ContinueOnError)
+	configFile := fs.String("config", "", "json configuration file")
+	bindFlags(fs, &cfg)
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if fs.NArg() > 0 {
+		return Config{}, errors.New("unexpected arguments")
+	}
+	if *configFile == "" {
+		return cfg, cfg.check()
+	}
+
+	// Load the file and override it with the explicitly set flags
+	fileCfg, err := loadConfig(*configFile)
+	if err != nil {
+		return Config{}, err
+	}
+	override := flag.NewFlagSet("override", flag.ContinueOnError)
+	bindFlags(override, &fileCfg)
+	var errSet error
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name != "config" && errSet == nil {
+			errSet = override.Set(f.Name, f.Value.String())
+		}
+	})
+	if errSet != nil {
+		return Config{}, errSet
+	}
+	return fileCfg, fileCfg.check()
+}
+
+// check the configuration consistency
+func (cfg Config) check() error {
+	switch {
+	case cfg.Population <= 0:
+		return errors.New("population size shall be > 0")
+	case cfg.Offspring < 0:
+		return errors.New("offspring size shall be >= 0")
+	case cfg.Every <= 0:
+		return errors.New("every shall be > 0")
+	case cfg.Log != "" && cfg.Log != "text" && cfg.Log != "json":
+		return errors.New("log shall be text or json")
+	default:
+		return nil
+	}
+}
+
+// termination builds the ending conditions using the configuration
+// If no min fitness is configured, the problem optimum is used (if known)
+func (cfg Config) termination(optimum float64) operator.MultiTermination {
+	termination := operator.MultiTermination{}
+	if cfg.Generations > 0 {
+		termination = termination.Use(&operator.GenerationTermination{K: cfg.Generations})
+	}
+	if cfg.Duration.Duration > 0 {
+		termination = termination.Use(&operator.DurationTermination{Duration: cfg.Duration.Duration})
+	}
+	if cfg.Improvement > 0 {
+		termination = termination.Use(&operator.ImprovementTermination{K: cfg.Improvement})
+	}
+	fitness := cfg.Fitness
+	if fitness == 0 {
+		fitness = optimum
+	}
+	if fitness > 0 {
+		termination = termination.Use(&operator.FitnessTermination{Fitness: fitness})
+	}
+	return termination
+}
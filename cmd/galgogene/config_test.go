@@ -0,0 +1,157 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfig(t *testing.T) {
+	Convey("parse args", t, func() {
+		Convey("when default", func() {
+			cfg, err := parseArgs(nil)
+			So(err, ShouldBeNil)
+			So(cfg, ShouldResemble, defaultConfig())
+		})
+
+		Convey("when flags", func() {
+			cfg, err := parseArgs([]string{"-problem", "tsp", "-pop", "42", "-duration", "1m", "-tsp.file", "cities.csv"})
+			So(err, ShouldBeNil)
+			So(cfg.Problem, ShouldEqual, "tsp")
+			So(cfg.Population, ShouldEqual, 42)
+			So(cfg.Duration.Duration, ShouldEqual, time.Minute)
+			So(cfg.TSP.File, ShouldEqual, "cities.csv")
+		})
+
+		Convey("when config file overridden by flags", func() {
+			filename := filepath.Join(t.TempDir(), "config.json")
+			err := os.WriteFile(filename, []byte(`{"problem": "benchmark", "pop": 0, "population": 10, "duration": "2s", "seed": 7}`), 0o644)
+			So(err, ShouldBeNil)
+			_, err = parseArgs([]string{"-config", filename})
+			So(err, ShouldNotBeNil) // unknown field "pop"
+
+			err = os.WriteFile(filename, []byte(`{"problem": "benchmark", "population": 10, "duration": "2s", "seed": 7}`), 0o644)
+			So(err, ShouldBeNil)
+			cfg, err := parseArgs([]string{"-seed", "42", "-config", filename})
+			So(err, ShouldBeNil)
+			So(cfg.Problem, ShouldEqual, "benchmark")
+			So(cfg.Population, ShouldEqual, 10)
+			So(cfg.Duration.Duration, ShouldEqual, 2*time.Second)
+			So(cfg.Seed, ShouldEqual, 42)
+			So(cfg.Generations, ShouldEqual, 1000) // default value kept
+		})
+
+		Convey("when invalid", func() {
+			_, err := parseArgs([]string{"-pop", "0"})
+			So(err, ShouldBeError, "population size shall be > 0")
+
+			_, err = parseArgs([]string{"-log", "xml"})
+			So(err, ShouldBeError, "log shall be text or json")
+		})
+	})
+
+	Convey("termination", t, func() {
+		Convey("when none", func() {
+			So(Config{}.termination(0), ShouldBeEmpty)
+		})
+
+		Convey("when problem optimum", func() {
+			So(Config{Generations: 10}.termination(1), ShouldResemble, operator.MultiTermination{
+				&operator.GenerationTermination{K: 10},
+				&operator.FitnessTermination{Fitness: 1},
+			})
+		})
+
+		Convey("when fitness overridden", func() {
+			So(Config{Fitness: 0.5}.termination(1), ShouldResemble, operator.MultiTermination{
+				&operator.FitnessTermination{Fitness: 0.5},
+			})
+		})
+	})
+}
+
+func TestProblems(t *testing.T) {
+	newChromosome := func(bases ...gene.B) gene.Chromosome {
+		chrm := gene.NewChromosome(len(bases), 1)
+		copy(chrm.Raw, bases)
+		return chrm
+	}
+
+	Convey("string matcher", t, func() {
+		sm, err := newStringMatcher(StringConfig{Target: "ABCD"})
+		So(err, ShouldBeNil)
+		So(sm.size(), ShouldEqual, 4)
+		So(sm.fitness(newChromosome('A', 'B', 'X', 'X')), ShouldEqual, 0.5)
+		So(sm.locus, ShouldResemble, gene.Printable)
+
+		sm, err = newStringMatcher(StringConfig{Target: "été"})
+		So(err, ShouldBeNil)
+		So(sm.locus, ShouldResemble, gene.Range(0, 255))
+	})
+
+	Convey("tsp", t, func() {
+		_, err := newTSP(TSPConfig{})
+		So(err, ShouldNotBeNil)
+
+		tp, err := newTSP(TSPConfig{File: "../../example/traveling_salesman/square.csv"})
+		So(err, ShouldBeNil)
+		So(tp.size(), ShouldEqual, 112)
+	})
+
+	Convey("knapsack", t, func() {
+		ks := knapsack{
+			items:    []item{{weight: 2, value: 3}, {weight: 4, value: 1}, {weight: 3, value: 5}},
+			capacity: 5,
+		}
+		So(ks.fitness(newChromosome(1, 0, 1)), ShouldEqual, 8)
+		So(ks.fitness(newChromosome(1, 1, 1)), ShouldEqual, 0) // too heavy
+	})
+
+	Convey("benchmark", t, func() {
+		bm, err := newBenchmark(BenchmarkConfig{Function: "sphere", Dimensions: 2, Bits: 2})
+		So(err, ShouldBeNil)
+		So(bm.size(), ShouldEqual, 4)
+		So(bm.decode(newChromosome(0, 0, 1, 1)), ShouldResemble, []float64{-5.12, 5.12})
+
+		gray, err := newBenchmark(BenchmarkConfig{Function: "sphere", Dimensions: 2, Bits: 2, Gray: true})
+		So(err, ShouldBeNil)
+		So(gray.decode(newChromosome(0, 0, 1, 0)), ShouldResemble, []float64{-5.12, 5.12})
+
+		_, err = newBenchmark(BenchmarkConfig{Function: "unknown", Dimensions: 2, Bits: 2})
+		So(err, ShouldNotBeNil)
+	})
+	Convey("seed", t, func() {
+		dir := t.TempDir()
+		output := func(name string) []byte {
+			filename := filepath.Join(dir, name)
+			err := run([]string{
+				"-problem", "knapsack", "-knapsack.items", "20", "-seed", "42",
+				"-pop", "20", "-generations", "10", "-every", "100", "-output", filename,
+			})
+			So(err, ShouldBeNil)
+			data, err := os.ReadFile(filename)
+			So(err, ShouldBeNil)
+			return data
+		}
+		So(output("run1.json"), ShouldResemble, output("run2.json"))
+	})
+
+	Convey("resume", t, func() {
+		filename := filepath.Join(t.TempDir(), "best.json")
+		So(result{Problem: "tsp", Code: []int{2, 0, 1}}.write(filename), ShouldBeNil)
+
+		chrm, err := readSeed(filename, "tsp", gene.PermutationInitializer{}, 3)
+		So(err, ShouldBeNil)
+		So(chrm.Raw, ShouldResemble, []gene.B{2, 0, 1})
+
+		_, err = readSeed(filename, "knapsack", gene.RandomInitializer{MaxValue: 1}, 3)
+		So(err, ShouldBeError, `resume: problem "tsp" shall be "knapsack"`)
+		_, err = readSeed(filename, "tsp", gene.PermutationInitializer{}, 4)
+		So(err, ShouldBeError, "resume: code size (3) shall be 4")
+	})
+}
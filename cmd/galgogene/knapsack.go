@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sbiemont/galgogene/dashboard"
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+	"github.com/sbiemont/galgogene/random"
+)
+
+// item that can be put in the knapsack
+type item struct {
+	weight float64
+	value  float64
+}
+
+// knapsack maximizes the value of the chosen items without exceeding the capacity (0-1 knapsack problem)
+// Each base tells if the item is chosen (1) or not (0)
+type knapsack struct {
+	items    []item
+	capacity float64
+}
+
+func newKnapsack(cfg KnapsackConfig) (knapsack, error) {
+	var items []item
+	if cfg.File != "" {
+		// Read items from file
+		data, err := readCSV(cfg.File, 2)
+		if err != nil {
+			return knapsack{}, err
+		}
+		items = make([]item, len(data))
+		for i, row := range data {
+			items[i] = item{weight: row[0], value: row[1]}
+		}
+	} else {
+		// Generate random items
+		items = make([]item, cfg.Items)
+		for i := range items {
+			items[i] = item{
+				weight: float64(random.IntN(100) + 1),
+				value:  float64(random.IntN(100) + 1),
+			}
+		}
+	}
+	if len(items) == 0 {
+		return knapsack{}, errors.New("knapsack: no item defined")
+	}
+
+	// Default capacity: half of the total weight
+	capacity := cfg.Capacity
+	if capacity <= 0 {
+		for _, it := range items {
+			capacity += it.weight / 2
+		}
+	}
+	return knapsack{
+		items:    items,
+		capacity: capacity,
+	}, nil
+}
+
+func (ks knapsack) size() int {
+	return len(ks.items)
+}
+
+func (ks knapsack) engine() engine.Engine {
+	return engine.Engine{
+		Initializer: gene.NewRandomInitializer(1),
+		Selection:   operator.TournamentSelection{Fighters: 3},
+		CrossOver:   operator.UniformCrossOver{},
+		Mutation: operator.MultiMutation{}.
+			Use(0.2, operator.UniqueMutation{}),
+		Survivor: operator.EliteSurvivor{},
+		Fitness:  ks.fitness,
+	}
+}
+
+// total weight and value of the chosen items
+func (ks knapsack) total(chrm gene.Chromosome) (float64, float64) {
+	var weight, value float64
+	for i, b := range chrm.Raw {
+		if b == 1 {
+			weight += ks.items[i].weight
+			value += ks.items[i].value
+		}
+	}
+	return weight, value
+}
+
+// fitness: total value of the chosen items (0 if the capacity is exceeded)
+func (ks knapsack) fitness(chrm gene.Chromosome) float64 {
+	weight, value := ks.total(chrm)
+	if weight > ks.capacity {
+		return 0
+	}
+	return value
+}
+
+func (ks knapsack) optimum() float64 {
+	return 0
+}
+
+func (ks knapsack) describe(chrm gene.Chromosome) string {
+	weight, value := ks.total(chrm)
+	var chosen []string
+	for i, b := range chrm.Raw {
+		if b == 1 {
+			chosen = append(chosen, fmt.Sprintf("%d", i))
+		}
+	}
+	return fmt.Sprintf(
+		"value: %.2f, weight: %.2f/%.2f, items: [%s]",
+		value, weight, ks.capacity, strings.Join(chosen, ", "),
+	)
+}
+
+func (knapsack) renderer() dashboard.Renderer {
+	return nil
+}
@@ -0,0 +1,179 @@
+// Command galgogene runs the built-in problems using the genetic algorithm engine
+//
+// Usage:
+//
+//	galgogene [-config file.json] [flags]
+//
+// Examples:
+//
+//	galgogene -problem string -string.target "Hello, World!"
+//	galgogene -problem tsp -tsp.file example/traveling_salesman/circle.csv -pop 600 -offspring 1200
+//	galgogene -problem knapsack -knapsack.items 100 -seed 42 -output best.json
+//	galgogene -problem knapsack -knapsack.items 100 -seed 42 -resume best.json
+//	galgogene -problem benchmark -benchmark.function ackley -history history.csv
+//	galgogene -problem knapsack -log json -every 10 2> run.log
+//	galgogene -problem tsp -tsp.file example/traveling_salesman/random.csv -generations 0 -http :8080
+//
+// Press Ctrl+C to stop processing: the best solution found so far is printed (and written).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sbiemont/galgogene/dashboard"
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/metrics"
+	"github.com/sbiemont/galgogene/operator"
+	"github.com/sbiemont/galgogene/random"
+)
+
+func main() {
+	err := run(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// interruptTermination ends processing when the context is done (eg.: SIGINT received)
+type interruptTermination struct {
+	ctx context.Context
+}
+
+func (end *interruptTermination) End(_, _, _ gene.Population) operator.Termination {
+	if end.ctx.Err() != nil {
+		return end
+	}
+	return nil
+}
+
+// newLogger builds the structured logger writing on stderr (nil if no format is defined)
+func newLogger(format string) *slog.Logger {
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, nil))
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	default:
+		return nil
+	}
+}
+
+func run(args []string) error {
+	cfg, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	// Seed first: random problems are also generated using the seed
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	random.Seed(seed)
+
+	prb, err := newProblem(cfg)
+	if err != nil {
+		return err
+	}
+	hst, err := newHistory(cfg.History)
+	if err != nil {
+		return err
+	}
+
+	// Stop at the end of the current generation on SIGINT / SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Live dashboard and prometheus metrics (optional)
+	var dsb *dashboard.Dashboard
+	var col *metrics.Collector
+	if cfg.HTTP != "" {
+		dsb = dashboard.New(prb.renderer())
+		col = metrics.NewCollector()
+		mux := http.NewServeMux()
+		mux.Handle("/", dsb)
+		mux.Handle("/metrics", col)
+		srv := &http.Server{Addr: cfg.HTTP, Handler: mux}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fmt.Fprintf(os.Stderr, "dashboard: %s\n", err)
+			}
+		}()
+		defer srv.Close()
+		fmt.Printf("Dashboard: http://%s, metrics: http://%s/metrics\n", cfg.HTTP, cfg.HTTP)
+	}
+
+	eng := prb.engine()
+	if cfg.Resume != "" {
+		seed, err := readSeed(cfg.Resume, cfg.Problem, eng.Initializer, prb.size())
+		if err != nil {
+			return err
+		}
+		eng.Seeds = []gene.Chromosome{seed}
+	}
+	eng.Termination = append(
+		operator.MultiTermination{&interruptTermination{ctx: ctx}},
+		cfg.termination(prb.optimum())...,
+	)
+	eng.Logging = engine.Logging{Logger: newLogger(cfg.Log), Every: cfg.Every}
+	if dsb != nil {
+		eng.Observers = append(eng.Observers, dsb, col)
+	}
+	eng.OnNewGeneration = func(pop, _, _ gene.Population) {
+		hst.add(pop)
+		if cfg.Log == "" && pop.Stats.GenerationNb%cfg.Every == 0 {
+			elite := pop.Elite()
+			fmt.Printf(
+				"Generation #%d, dur: %4dms, fit: %f, tot: %f, uniq: %d/%d, %s\n",
+				pop.Stats.GenerationNb,
+				pop.Stats.TotalDuration.Milliseconds(),
+				elite.Fitness,
+				pop.Stats.TotalFitness,
+				len(pop.Unique()),
+				pop.Len(),
+				prb.describe(elite.Code),
+			)
+		}
+	}
+
+	fmt.Printf("Problem: %s, seed: %d\n", cfg.Problem, seed)
+	sol, errRun := eng.Run(cfg.Population, cfg.Offspring, prb.size())
+	errHst := hst.Close()
+	if errRun != nil {
+		return errRun
+	}
+	if errHst != nil {
+		return errHst
+	}
+
+	// Print (and write) the best individual
+	res := newResult(cfg, seed, prb, sol)
+	if _, ok := sol.Termination.(*interruptTermination); ok {
+		fmt.Println("\nInterrupted, best solution so far")
+	}
+	fmt.Printf(
+		"\nBest individual, gen: #%d, fit: %f, termination: %s\n%s\n",
+		res.Generation,
+		res.Fitness,
+		res.Termination,
+		res.Solution,
+	)
+	if cfg.Output != "" {
+		return res.write(cfg.Output)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+)
+
+// result is the json output of the best solution found
+type result struct {
+	Problem     string  `json:"problem"`
+	Seed        uint64  `json:"seed"`
+	Generation  int     `json:"generation"`
+	Fitness     float64 `json:"fitness"`
+	Termination string  `json:"termination"`
+	Solution    string  `json:"solution"`
+	Code        []int   `json:"code"`
+}
+
+// newResult builds the output of the best individual of the solution
+func newResult(cfg Config, seed uint64, prb problem, sol engine.Solution) result {
+	pop := sol.PopWithBestIndividual
+	elite := pop.Elite()
+	code := make([]int, elite.Code.Len())
+	for i, b := range elite.Code.Raw {
+		code[i] = int(b)
+	}
+	return result{
+		Problem:     cfg.Problem,
+		Seed:        seed,
+		Generation:  pop.Stats.GenerationNb,
+		Fitness:     elite.Fitness,
+		Termination: terminationName(sol),
+		Solution:    prb.describe(elite.Code),
+		Code:        code,
+	}
+}
+
+// terminationName returns the short type name of the termination that ended processing
+func terminationName(sol engine.Solution) string {
+	if sol.Termination == nil {
+		return ""
+	}
+	name := fmt.Sprintf("%T", sol.Termination)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// write the result into a json file
+func (res result) write(filename string) error {
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
+
+// readSeed reads the solution of a previous run (json output) to seed the initial population
+// The chromosome is built by the initializer to inherit its properties
+func readSeed(filename, problem string, initializer gene.Initializer, size int) (gene.Chromosome, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return gene.Chromosome{}, err
+	}
+	var res result
+	if err := json.Unmarshal(data, &res); err != nil {
+		return gene.Chromosome{}, err
+	}
+	if res.Problem != problem {
+		return gene.Chromosome{}, fmt.Errorf("resume: problem %q shall be %q", res.Problem, problem)
+	}
+	if len(res.Code) != size {
+		return gene.Chromosome{}, fmt.Errorf("resume: code size (%d) shall be %d", len(res.Code), size)
+	}
+
+	chrm, err := initializer.Init(size)
+	if err != nil {
+		return gene.Chromosome{}, err
+	}
+	for i, value := range res.Code {
+		if value < 0 || value > math.MaxUint8 {
+			return gene.Chromosome{}, fmt.Errorf("resume: invalid code value %d", value)
+		}
+		chrm.Raw[i] = gene.B(value)
+	}
+	return chrm, nil
+}
+
+// history writes the stats of each generation into a csv file
+// A nil history writes nothing
+type history struct {
+	f   *os.File
+	wr  *csv.Writer
+	err error // first error encountered
+}
+
+// newHistory creates the csv file and writes the header (no history if filename is empty)
+func newHistory(filename string) (*history, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	hst := &history{
+		f:  f,
+		wr: csv.NewWriter(f),
+	}
+	hst.err = hst.wr.Write([]string{"generation", "duration", "elite_fitness", "mean_fitness", "total_fitness", "unique"})
+	return hst, nil
+}
+
+// add the population stats to the history
+func (hst *history) add(pop gene.Population) {
+	if hst == nil || hst.err != nil {
+		return
+	}
+	hst.err = hst.wr.Write([]string{
+		fmt.Sprintf("%d", pop.Stats.GenerationNb),
+		fmt.Sprintf("%.6f", pop.Stats.TotalDuration.Seconds()),
+		fmt.Sprintf("%g", pop.Elite().Fitness),
+		fmt.Sprintf("%g", pop.Stats.TotalFitness/float64(pop.Len())),
+		fmt.Sprintf("%g", pop.Stats.TotalFitness),
+		fmt.Sprintf("%d", len(pop.Unique())),
+	})
+}
+
+// Close flushes the data and closes the file
+// Returns the first error encountered
+func (hst *history) Close() error {
+	if hst == nil {
+		return nil
+	}
+	hst.wr.Flush()
+	if hst.err == nil {
+		hst.err = hst.wr.Error()
+	}
+	if err := hst.f.Close(); hst.err == nil {
+		hst.err = err
+	}
+	return hst.err
+}
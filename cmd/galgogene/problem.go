@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/sbiemont/galgogene/dashboard"
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+)
+
+// problem defines a built-in problem to be solved by the engine
+type problem interface {
+	// size returns the number of bases in a chromosome
+	size() int
+	// engine returns the engine operators and fitness (termination excluded)
+	engine() engine.Engine
+	// optimum returns the best reachable fitness (0 if unknown)
+	optimum() float64
+	// describe converts a chromosome into a human readable solution
+	describe(chrm gene.Chromosome) string
+	// renderer returns the live dashboard rendering of an individual (nil if none)
+	renderer() dashboard.Renderer
+}
+
+// newProblem builds the configured problem
+func newProblem(cfg Config) (problem, error) {
+	switch cfg.Problem {
+	case "string":
+		return newStringMatcher(cfg.String)
+	case "tsp":
+		return newTSP(cfg.TSP)
+	case "knapsack":
+		return newKnapsack(cfg.Knapsack)
+	case "benchmark":
+		return newBenchmark(cfg.Benchmark)
+	default:
+		return nil, fmt.Errorf("unknown problem %q", cfg.Problem)
+	}
+}
+
+// readCSV reads a csv file of float values, each row shall have the expected number of columns
+func readCSV(filename string, nbColumns int) ([][]float64, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rd := csv.NewReader(f)
+	rd.FieldsPerRecord = nbColumns
+	data, err := rd.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert [][]string to [][]float64
+	result := make([][]float64, len(data))
+	for i, row := range data {
+		result[i] = make([]float64, nbColumns)
+		for j, col := range row {
+			value, err := strconv.ParseFloat(col, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s, line %d: %w", filename, i+1, err)
+			}
+			result[i][j] = value
+		}
+	}
+	return result, nil
+}
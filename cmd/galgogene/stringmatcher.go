@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/sbiemont/galgogene/dashboard"
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+)
+
+// stringMatcher finds a target string char by char
+type stringMatcher struct {
+	target string
+	locus  gene.Locus // Allowed chars
+}
+
+func newStringMatcher(cfg StringConfig) (stringMatcher, error) {
+	if cfg.Target == "" {
+		return stringMatcher{}, errors.New("string matcher: target cannot be empty")
+	}
+
+	// Only search printable chars, unless the target needs other bytes
+	locus := gene.Printable
+	for i := range len(cfg.Target) {
+		if locus.IndexOf(gene.B(cfg.Target[i])) < 0 {
+			locus = gene.Range(0, 255)
+			break
+		}
+	}
+	return stringMatcher{target: cfg.Target, locus: locus}, nil
+}
+
+func (sm stringMatcher) size() int {
+	return len(sm.target)
+}
+
+func (sm stringMatcher) engine() engine.Engine {
+	return engine.Engine{
+		Initializer: gene.SpecInitializer{Spec: gene.UniformSpec(sm.locus)},
+		Selection: operator.MultiSelection{}.
+			Use(0.5, operator.TournamentSelection{Fighters: 10}).
+			Otherwise(operator.RouletteSelection{}),
+		CrossOver: operator.MultiCrossOver{}.
+			Use(0.1, operator.OnePointCrossOver{}).
+			Use(0.5, operator.UniformCrossOver{}),
+		Mutation: operator.MultiMutation{}.
+			Use(0.95, operator.UniqueMutation{}).
+			Use(0.05, operator.UniformMutation{}),
+		Survivor: operator.MultiSurvivor{}.
+			Use(0.01, operator.RankSurvivor{}).
+			Otherwise(operator.EliteSurvivor{}),
+		Fitness: sm.fitness,
+	}
+}
+
+// fitness: ratio of matching chars
+func (sm stringMatcher) fitness(chrm gene.Chromosome) float64 {
+	var fitness float64
+	for i, b := range chrm.Raw {
+		if sm.target[i] == b.Byte() {
+			fitness += 1
+		}
+	}
+	return fitness / float64(chrm.Len())
+}
+
+func (sm stringMatcher) optimum() float64 {
+	return 1
+}
+
+func (sm stringMatcher) describe(chrm gene.Chromosome) string {
+	return strconv.Quote(chrm.String())
+}
+
+func (stringMatcher) renderer() dashboard.Renderer {
+	return nil
+}
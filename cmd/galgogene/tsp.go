@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sbiemont/galgogene/dashboard"
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+)
+
+// maxCities is the max number of cities that can be stored in a chromosome
+const maxCities = 256
+
+// tsp finds the shortest tour visiting all cities (traveling salesman problem)
+type tsp struct {
+	coordinates [][]float64 // coordinates[i] gives (x,y) of city #i
+	distances   [][]float64 // distances[i][j] gives the distance between city #i and city #j
+}
+
+func newTSP(cfg TSPConfig) (tsp, error) {
+	if cfg.File == "" {
+		return tsp{}, errors.New("tsp: file shall be defined")
+	}
+	coordinates, err := readCSV(cfg.File, 2)
+	if err != nil {
+		return tsp{}, err
+	}
+	if len(coordinates) < 2 || len(coordinates) > maxCities {
+		return tsp{}, fmt.Errorf("tsp: number of cities shall be in [2 ; %d]", maxCities)
+	}
+
+	// Compute the distances matrix once
+	n := len(coordinates)
+	distances := make([][]float64, n)
+	for i := range n {
+		distances[i] = make([]float64, n)
+		for j := range n {
+			dx := coordinates[i][0] - coordinates[j][0]
+			dy := coordinates[i][1] - coordinates[j][1]
+			distances[i][j] = math.Sqrt(dx*dx + dy*dy)
+		}
+	}
+	return tsp{
+		coordinates: coordinates,
+		distances:   distances,
+	}, nil
+}
+
+func (t tsp) size() int {
+	return len(t.coordinates)
+}
+
+func (t tsp) engine() engine.Engine {
+	return engine.Engine{
+		Initializer: gene.MultiInitializer{}.
+			Use(0.1, gene.RandomizedGreedyInitializer{Cost: gene.MatrixCost(t.distances), Alpha: 0.2}).
+			Otherwise(gene.PermutationInitializer{}),
+		Selection: operator.MultiSelection{}.
+			Use(0.01, operator.EliteSelection{}).
+			Otherwise(operator.RouletteSelection{}),
+		CrossOver: operator.MultiCrossOver{}.
+			Use(0.1, operator.UniformOrderCrossOver{}).
+			Use(1.0, operator.DavisOrderCrossOver{}),
+		Mutation: operator.MultiMutation{}.
+			Use(0.06, operator.InversionPermutation{}).
+			Use(0.05, operator.SwapPermutation{}).
+			Use(0.05, operator.ScramblePermutation{}),
+		Survivor: operator.MultiSurvivor{}.
+			Use(0.6, operator.EliteSurvivor{}).
+			Otherwise(operator.RandomSurvivor{}),
+		Fitness: t.fitness,
+	}
+}
+
+// distance of the closed tour [A, B, C, D]
+// dist = A->B + B->C + C->D + D->A
+func (t tsp) distance(chrm gene.Chromosome) float64 {
+	var distance float64
+	for i, city := range chrm.Raw {
+		next := chrm.Raw[(i+1)%chrm.Len()]
+		distance += t.distances[city][next]
+	}
+	return distance
+}
+
+// fitness: inverse of the tour distance
+func (t tsp) fitness(chrm gene.Chromosome) float64 {
+	distance := t.distance(chrm)
+	if distance == 0 {
+		return 0
+	}
+	return 1.0 / distance
+}
+
+func (t tsp) optimum() float64 {
+	return 0
+}
+
+func (t tsp) describe(chrm gene.Chromosome) string {
+	cities := make([]string, chrm.Len())
+	for i, city := range chrm.Raw {
+		cities[i] = fmt.Sprintf("%d", city)
+	}
+	return fmt.Sprintf("distance: %.2f, tour: [%s]", t.distance(chrm), strings.Join(cities, ", "))
+}
+
+// renderer draws the tour
+func (t tsp) renderer() dashboard.Renderer {
+	coordinates := make([][2]float64, len(t.coordinates))
+	for i, coord := range t.coordinates {
+		coordinates[i] = [2]float64{coord[0], coord[1]}
+	}
+	return dashboard.TourRenderer(coordinates)
+}
@@ -0,0 +1,195 @@
+package dashboard
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// maxHistory is the max number of generations kept in memory
+const maxHistory = 10000
+
+// Renderer draws an individual as an svg image (eg.: the tour of a traveling salesman)
+type Renderer func(ind gene.Individual) string
+
+// Stats gathers the data of one generation sent to the browser
+type Stats struct {
+	Generation int     `json:"generation"`    // Generation number
+	Duration   float64 `json:"duration"`      // Total duration (in seconds)
+	Elite      float64 `json:"elite"`         // Fitness of the best individual of the generation
+	Mean       float64 `json:"mean"`          // Mean fitness of the generation
+	Best       float64 `json:"best"`          // Fitness of the best individual ever computed
+	Diversity  float64 `json:"diversity"`     // Ratio of unique individuals in the generation
+	SVG        string  `json:"svg,omitempty"` // Rendering of the best individual ever computed (only when changed)
+}
+
+// Dashboard is an http handler streaming the stats of each generation to a browser
+// * "/":       the static page charting the stats
+// * "/events": the server-sent events stream of the stats
+type Dashboard struct {
+	renderer Renderer
+	mux      *http.ServeMux
+
+	mu       sync.Mutex
+	history  []Stats                 // last stats (without svg)
+	svg      string                  // last svg rendered
+	rendered bool                    // true when the svg has been rendered at least once
+	clients  map[chan Stats]struct{} // all connected browsers
+}
+
+// New builds a dashboard, the renderer is optional
+func New(renderer Renderer) *Dashboard {
+	dsb := &Dashboard{
+		renderer: renderer,
+		mux:      http.NewServeMux(),
+		clients:  make(map[chan Stats]struct{}),
+	}
+	dsb.mux.HandleFunc("/events", dsb.serveEvents)
+	dsb.mux.HandleFunc("/", dsb.serveIndex)
+	return dsb
+}
+
+// ServeHTTP serves the page and the events
+// To be mounted on a sub-path, use http.StripPrefix
+func (dsb *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	dsb.mux.ServeHTTP(w, r)
+}
+
+// OnNewGeneration records the stats of the new generation and sends them to all connected browsers
+// It matches the engine OnNewGeneration signature and never blocks on slow browsers
+func (dsb *Dashboard) OnNewGeneration(pop, withBestIndividual, _ gene.Population) {
+	stats := Stats{
+		Generation: pop.Stats.GenerationNb,
+		Duration:   pop.Stats.TotalDuration.Seconds(),
+		Elite:      pop.Elite().Fitness,
+		Mean:       pop.Stats.TotalFitness / float64(pop.Len()),
+		Best:       withBestIndividual.Elite().Fitness,
+		Diversity:  pop.Diversity(),
+	}
+
+	dsb.mu.Lock()
+	defer dsb.mu.Unlock()
+
+	// A new run restarts the generations
+	if len(dsb.history) > 0 && stats.Generation <= dsb.history[len(dsb.history)-1].Generation {
+		dsb.history = nil
+		dsb.rendered = false
+	}
+
+	// Render the best individual only when it has changed
+	if dsb.renderer != nil {
+		last := len(dsb.history) - 1
+		if !dsb.rendered || stats.Best > dsb.history[last].Best {
+			dsb.svg = dsb.renderer(withBestIndividual.Elite())
+			dsb.rendered = true
+			stats.SVG = dsb.svg
+		}
+	}
+
+	// Keep history without svg
+	history := stats
+	history.SVG = ""
+	dsb.history = append(dsb.history, history)
+	if len(dsb.history) > maxHistory {
+		dsb.history = dsb.history[len(dsb.history)-maxHistory:]
+	}
+
+	// Broadcast, drop the stats for browsers that are not fast enough
+	for ch := range dsb.clients {
+		select {
+		case ch <- stats:
+		default:
+		}
+	}
+}
+
+// Notify records each generation (initial population included)
+// It implements the engine Observer interface
+func (dsb *Dashboard) Notify(evt engine.Event) {
+	if evt.Kind == engine.EventInitialPopulation || evt.Kind == engine.EventGeneration {
+		dsb.OnNewGeneration(evt.Population, evt.WithBestIndividual, evt.WithBestTotalFitness)
+	}
+}
+
+// subscribe registers a new browser and returns the history to be sent first
+func (dsb *Dashboard) subscribe() (chan Stats, []Stats) {
+	dsb.mu.Lock()
+	defer dsb.mu.Unlock()
+
+	ch := make(chan Stats, 100)
+	dsb.clients[ch] = struct{}{}
+	history := make([]Stats, len(dsb.history))
+	copy(history, dsb.history)
+	if len(history) > 0 {
+		history[len(history)-1].SVG = dsb.svg
+	}
+	return ch, history
+}
+
+// unsubscribe removes a disconnected browser
+func (dsb *Dashboard) unsubscribe(ch chan Stats) {
+	dsb.mu.Lock()
+	defer dsb.mu.Unlock()
+	delete(dsb.clients, ch)
+}
+
+// serveIndex serves the static page
+func (dsb *Dashboard) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(indexHTML)
+}
+
+// serveEvents streams the stats of each generation (history first)
+func (dsb *Dashboard) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch, history := dsb.subscribe()
+	defer dsb.unsubscribe(ch)
+	for _, stats := range history {
+		if err := writeEvent(w, stats); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case stats := <-ch:
+			if err := writeEvent(w, stats); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent writes the stats as a server-sent event
+func writeEvent(w http.ResponseWriter, stats Stats) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
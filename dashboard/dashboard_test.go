@@ -0,0 +1,128 @@
+package dashboard
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newPopulation(generationNb int, fitnesses ...float64) gene.Population {
+	pop := gene.NewPopulation(len(fitnesses))
+	for i, fitness := range fitnesses {
+		pop.Individuals[i].Code = gene.NewChromosome(2, 1)
+		pop.Individuals[i].Code.Raw[0] = gene.B(i)
+		pop.Individuals[i].Fitness = fitness
+	}
+	pop.ComputeTotalFitness()
+	pop.Stats.GenerationNb = generationNb
+	return pop
+}
+
+func TestDashboard(t *testing.T) {
+	Convey("dashboard", t, func() {
+		var renders int
+		dsb := New(func(ind gene.Individual) string {
+			renders++
+			return "<svg/>"
+		})
+
+		Convey("when new generations", func() {
+			pop0 := newPopulation(0, 0.1, 0.3)
+			dsb.OnNewGeneration(pop0, pop0, pop0)
+			pop1 := newPopulation(1, 0.2, 0.2)
+			dsb.OnNewGeneration(pop1, pop0, pop0) // same best individual
+			pop2 := newPopulation(2, 0.5, 0.1)
+			dsb.OnNewGeneration(pop2, pop2, pop2)
+
+			So(renders, ShouldEqual, 2)
+			So(dsb.history, ShouldResemble, []Stats{
+				{Generation: 0, Elite: 0.3, Mean: 0.2, Best: 0.3, Diversity: 1},
+				{Generation: 1, Elite: 0.2, Mean: 0.2, Best: 0.3, Diversity: 1},
+				{Generation: 2, Elite: 0.5, Mean: 0.3, Best: 0.5, Diversity: 1},
+			})
+
+			Convey("when a new run starts", func() {
+				dsb.OnNewGeneration(pop0, pop0, pop0)
+				So(renders, ShouldEqual, 3)
+				So(dsb.history, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("when notified", func() {
+			pop0 := newPopulation(0, 0.1, 0.3)
+			dsb.Notify(engine.Event{Kind: engine.EventStart})
+			dsb.Notify(engine.Event{Kind: engine.EventInitialPopulation, Population: pop0, WithBestIndividual: pop0})
+			dsb.Notify(engine.Event{Kind: engine.EventNewBest, Population: pop0, WithBestIndividual: pop0})
+			pop1 := newPopulation(1, 0.2, 0.2)
+			dsb.Notify(engine.Event{Kind: engine.EventGeneration, Population: pop1, WithBestIndividual: pop0})
+			So(dsb.history, ShouldResemble, []Stats{
+				{Generation: 0, Elite: 0.3, Mean: 0.2, Best: 0.3, Diversity: 1},
+				{Generation: 1, Elite: 0.2, Mean: 0.2, Best: 0.3, Diversity: 1},
+			})
+		})
+
+		Convey("when index", func() {
+			rec := httptest.NewRecorder()
+			dsb.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			So(rec.Code, ShouldEqual, http.StatusOK)
+			So(rec.Body.String(), ShouldContainSubstring, `new EventSource("events")`)
+
+			rec = httptest.NewRecorder()
+			dsb.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+			So(rec.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("when events", func() {
+			pop0 := newPopulation(0, 0.1, 0.3)
+			dsb.OnNewGeneration(pop0, pop0, pop0)
+
+			srv := httptest.NewServer(dsb)
+			defer srv.Close()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events", nil)
+			So(err, ShouldBeNil)
+			resp, err := http.DefaultClient.Do(req)
+			So(err, ShouldBeNil)
+			defer resp.Body.Close()
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "text/event-stream")
+
+			// Read the next event
+			rd := bufio.NewReader(resp.Body)
+			next := func() Stats {
+				line, err := rd.ReadString('\n')
+				So(err, ShouldBeNil)
+				_, _ = rd.ReadString('\n') // empty line
+				var stats Stats
+				So(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &stats), ShouldBeNil)
+				return stats
+			}
+
+			// History first (with the last rendering), then live events
+			So(next(), ShouldResemble, Stats{Generation: 0, Elite: 0.3, Mean: 0.2, Best: 0.3, Diversity: 1, SVG: "<svg/>"})
+			pop1 := newPopulation(1, 0.2, 0.2)
+			dsb.OnNewGeneration(pop1, pop0, pop0)
+			So(next(), ShouldResemble, Stats{Generation: 1, Elite: 0.2, Mean: 0.2, Best: 0.3, Diversity: 1})
+		})
+	})
+}
+
+func TestTourRenderer(t *testing.T) {
+	Convey("tour renderer", t, func() {
+		render := TourRenderer([][2]float64{{0, 0}, {10, 0}, {10, 10}})
+		ind := gene.Individual{Code: gene.NewChromosome(3, 3)}
+		ind.Code.Raw = []gene.B{0, 2, 1}
+		svg := render(ind)
+		So(svg, ShouldStartWith, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="-0.5 -0.5 11 11">`)
+		So(svg, ShouldContainSubstring, `<polygon points="0,0 10,10 10,0"`)
+		So(strings.Count(svg, "<circle"), ShouldEqual, 3)
+	})
+}
@@ -0,0 +1,54 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sbiemont/galgogene/gene"
+)
+
+// TourRenderer draws a closed tour of cities (traveling salesman problem) as an svg image
+// coordinates[i] gives (x,y) of city #i, each base of the chromosome is a city number
+func TourRenderer(coordinates [][2]float64) Renderer {
+	// Compute the bounding box once
+	var xMin, yMin, xMax, yMax float64
+	for i, coord := range coordinates {
+		if i == 0 {
+			xMin, xMax, yMin, yMax = coord[0], coord[0], coord[1], coord[1]
+		}
+		xMin, xMax = min(xMin, coord[0]), max(xMax, coord[0])
+		yMin, yMax = min(yMin, coord[1]), max(yMax, coord[1])
+	}
+	margin := max(xMax-xMin, yMax-yMin) * 0.05
+	radius := max(margin/5, 0.5)
+
+	return func(ind gene.Individual) string {
+		var sb strings.Builder
+		fmt.Fprintf(
+			&sb,
+			`<svg xmlns="http://www.w3.org/2000/svg" viewBox="%g %g %g %g">`,
+			xMin-margin, yMin-margin, xMax-xMin+2*margin, yMax-yMin+2*margin,
+		)
+
+		// Tour
+		points := make([]string, 0, ind.Code.Len())
+		for _, city := range ind.Code.Raw {
+			if int(city) < len(coordinates) {
+				coord := coordinates[city]
+				points = append(points, fmt.Sprintf("%g,%g", coord[0], coord[1]))
+			}
+		}
+		fmt.Fprintf(
+			&sb,
+			`<polygon points="%s" fill="none" stroke="blue" stroke-width="%g"/>`,
+			strings.Join(points, " "), radius/2,
+		)
+
+		// Cities
+		for _, coord := range coordinates {
+			fmt.Fprintf(&sb, `<circle cx="%g" cy="%g" r="%g" fill="red"/>`, coord[0], coord[1], radius)
+		}
+		sb.WriteString("</svg>")
+		return sb.String()
+	}
+}
@@ -12,18 +12,30 @@ import (
 type Engine struct {
 	Initializer     gene.Initializer
 	Selection       operator.Selection
+	Scaling         operator.Scaling // Optional fitness scaling applied before the selection
 	CrossOver       operator.CrossOver
 	Mutation        operator.Mutation
 	Survivor        operator.Survivor
 	Termination     operator.Termination
 	Fitness         gene.Fitness
+	CaseFitness     gene.CaseFitness  // Optional score of each test case (eg.: for lexicase selections), also used as fitness if not set
+	Seeds           []gene.Chromosome // Optional known solutions injected into the initial population
 	OnNewGeneration func(pop gene.Population, withBestIndividual gene.Population, withBestTotalFitness gene.Population)
+	Observers       []Observer // Optional observers notified of each event of the run
+	Stagnation      int        // Number of generations without improvement raising a stagnation event (0: never)
+	Restart         bool       // Restart from a new initial population (keeping the best individual) on each stagnation event
+	Logging         Logging    // Optional structured logs of the run
 }
 
 func (eng Engine) check() error {
+	// The operators of a genome shall apply on each of its chromosomes
+	_, genome := eng.Initializer.(gene.GenomeInitializer)
+	_, genomeCrossOver := eng.CrossOver.(operator.GenomeCrossOver)
+	_, genomeMutation := eng.Mutation.(operator.GenomeMutation)
+
 	// Check presence
 	switch {
-	case eng.Fitness == nil:
+	case eng.Fitness == nil && eng.CaseFitness == nil:
 		return errors.New("fitness must be set")
 	case eng.Initializer == nil:
 		return errors.New("initializer must be set")
@@ -35,6 +47,10 @@ func (eng Engine) check() error {
 		return errors.New("survivor must be set")
 	case eng.Termination == nil:
 		return errors.New("termination must be set")
+	case genome && !genomeCrossOver:
+		return errors.New("crossover must be a genome crossover for a genome initializer")
+	case genome && eng.Mutation != nil && !genomeMutation:
+		return errors.New("mutation must be a genome mutation for a genome initializer")
 	default:
 		return nil
 	}
@@ -46,7 +62,9 @@ func (eng Engine) check() error {
 // * chromosomeSize: number of bases in a chromosome (in one individual)
 func (eng Engine) Run(popSize, offspringSize, chromosomeSize int) (Solution, error) {
 	start := time.Now()
+	lgr := eng.logger()
 	if err := eng.check(); err != nil {
+		lgr.error(err)
 		return Solution{}, err
 	}
 
@@ -62,136 +80,315 @@ func (eng Engine) Run(popSize, offspringSize, chromosomeSize int) (Solution, err
 	}
 	popSize = makeEven(popSize)
 	offspringSize = makeEven(offspringSize)
+	lgr.start(eng, popSize, offspringSize, chromosomeSize)
+	eng.notify(Event{Kind: EventStart})
 
 	// Init first pop
 	population := gene.NewPopulation(popSize)
-	errInit := population.Init(chromosomeSize, eng.Initializer, eng.Fitness)
+	errInit := population.InitWith(chromosomeSize, eng.Initializer, eng.evaluator(), eng.Seeds...)
 	if errInit != nil {
+		lgr.error(errInit)
 		return Solution{}, errInit
 	}
-	eng.onNewGeneration(population, population, population)
+	eng.scale(&population)
+	population.ComputeTotalFitness()
+	population.Stats.Evaluations = popSize
+	eng.onNewGeneration(EventInitialPopulation, population, population, population)
 
 	// New channels
-	chSelection := make(chan gene.Population, 1)
-	chCrossover := make(chan gene.Chromosome, 20)
-	chMutation := make(chan gene.Chromosome, 20)
+	chBreeding := make(chan gene.Population, 1)
 	chFitness := make(chan gene.Chromosome, 20)
 	chIndividuals := make(chan gene.Individual, 20)
 	chOffsprings := make(chan gene.Population)
 	chErr := make(chan error)
 	chSolution := make(chan Solution)
 
-	go eng.selection(offspringSize, chSelection, chCrossover, chErr)
-	go eng.crossover(chCrossover, chMutation)
-	go eng.mutation(chMutation, chFitness)
-	go eng.fitness(chFitness, chIndividuals)
+	tmr := &timer{}
+	go eng.breeding(offspringSize, tmr, chBreeding, chFitness, chErr)
+	go eng.fitness(tmr, chFitness, chIndividuals)
 	go eng.offsprings(offspringSize, chIndividuals, chOffsprings)
 
 	defer close(chErr)
 	defer close(chSolution)
 
 	// Run until an ending condition or an error is found
-	go eng.run(start, population, chSelection, chOffsprings, chSolution)
+	go eng.run(start, tmr, population, chBreeding, chOffsprings, chSolution, chErr)
 	select {
 	case sol := <-chSolution:
 		return sol, nil
 	case err := <-chErr:
+		lgr.error(err)
 		return Solution{}, err
 	}
 }
 
 func (eng Engine) run(
 	start time.Time,
+	tmr *timer,
 	population gene.Population,
-	chSelection chan<- gene.Population,
+	chBreeding chan<- gene.Population,
 	chOffsprings <-chan gene.Population,
 	chSolution chan<- Solution,
+	chErr chan<- error,
 ) {
+	defer close(chBreeding) // stop all processes
 	withBestIndividual := population
 	withBestTotalFit := population
+	stg := stagnation{k: eng.Stagnation}
 
 	for {
 		// End ?
 		termination := eng.Termination.End(population, withBestIndividual, withBestTotalFit)
 		if termination != nil {
-			chSolution <- Solution{
+			sol := Solution{
 				PopWithBestIndividual:   withBestIndividual,
 				PopWithBestTotalFitness: withBestTotalFit,
 				Termination:             termination,
 			}
+			eng.logger().termination(population, sol)
+			eng.notify(Event{
+				Kind:                 EventTermination,
+				Population:           population,
+				WithBestIndividual:   withBestIndividual,
+				WithBestTotalFitness: withBestTotalFit,
+				Termination:          termination,
+			})
+			chSolution <- sol
 			return
 		}
 
-		// Start selection process
-		chSelection <- population
+		// Start breeding process
+		chBreeding <- population
 
-		// Wait for offspring to be ready
-		offsprings := <-chOffsprings
-		population = eng.survivors(start, population, offsprings)
+		// Wait for offspring to be ready (the processes are closed on error)
+		offsprings, ok := <-chOffsprings
+		if !ok {
+			return
+		}
+		population = eng.survivors(start, tmr, population, offsprings)
 
 		// Custom action
 		if population.Stats.TotalFitness > withBestTotalFit.Stats.TotalFitness {
 			withBestTotalFit = population
 		}
-		if population.Stats.Elite.Fitness > withBestIndividual.Stats.Elite.Fitness {
+		improved := population.Stats.Elite.Fitness > withBestIndividual.Stats.Elite.Fitness
+		if improved {
 			withBestIndividual = population
 		}
-		eng.onNewGeneration(population, withBestIndividual, withBestTotalFit)
+		eng.onNewGeneration(EventGeneration, population, withBestIndividual, withBestTotalFit)
+		if improved {
+			eng.notify(Event{
+				Kind:                 EventNewBest,
+				Population:           population,
+				WithBestIndividual:   withBestIndividual,
+				WithBestTotalFitness: withBestTotalFit,
+			})
+		}
+		if stg.next(improved) {
+			eng.logger().stagnation(population, withBestIndividual)
+			eng.notify(Event{
+				Kind:                 EventStagnation,
+				Population:           population,
+				WithBestIndividual:   withBestIndividual,
+				WithBestTotalFitness: withBestTotalFit,
+			})
+			if eng.Restart {
+				restarted, err := eng.restart(population, withBestIndividual)
+				if err != nil {
+					chErr <- err
+					return
+				}
+				population = restarted
+				eng.logger().restart(population)
+				eng.notify(Event{
+					Kind:                 EventRestart,
+					Population:           population,
+					WithBestIndividual:   withBestIndividual,
+					WithBestTotalFitness: withBestTotalFit,
+				})
+			}
+		}
 	}
 }
 
-// onNewGeneration calls the user method (only if defined)
-func (eng Engine) onNewGeneration(population, withBestIndividual, withBestTotalFit gene.Population) {
+// restart builds a new initial population with the best individual found so far
+// The generation number, the number of evaluations and the duration go on
+func (eng Engine) restart(population, withBestIndividual gene.Population) (gene.Population, error) {
+	elite := withBestIndividual.Elite()
+	newPop := gene.NewPopulation(population.Len())
+	err := newPop.InitWith(elite.Code.Len(), eng.Initializer, eng.evaluator(), elite.Code)
+	if err != nil {
+		return gene.Population{}, err
+	}
+	eng.scale(&newPop)
+	newPop.ComputeTotalFitness()
+	newPop.ComputeRank()
+	newPop.Stats.GenerationNb = population.Stats.GenerationNb
+	newPop.Stats.Evaluations = population.Stats.Evaluations + newPop.Len()
+	newPop.Stats.TotalDuration = population.Stats.TotalDuration
+	return newPop, nil
+}
+
+// onNewGeneration logs the generation, calls the user method (only if defined) and notifies the observers
+func (eng Engine) onNewGeneration(kind EventKind, population, withBestIndividual, withBestTotalFit gene.Population) {
+	eng.logger().generation(population)
 	if eng.OnNewGeneration != nil {
 		eng.OnNewGeneration(population, withBestIndividual, withBestTotalFit)
 	}
+	eng.notify(Event{
+		Kind:                 kind,
+		Population:           population,
+		WithBestIndividual:   withBestIndividual,
+		WithBestTotalFitness: withBestTotalFit,
+	})
+}
+
+// notify all observers (in the registration order)
+func (eng Engine) notify(evt Event) {
+	for _, obs := range eng.Observers {
+		obs.Notify(evt)
+	}
+}
+
+// logger returns the run logger
+func (eng Engine) logger() logger {
+	return logger{eng.Logging}
+}
+
+// arity returns the number of parents and children of each crossover (2 and 2, unless it is a multi-parent crossover)
+func (eng Engine) arity() (int, int) {
+	if co, ok := eng.CrossOver.(operator.MultiParentCrossOver); ok {
+		parents, children := co.Arity()
+		return max(parents, 1), max(children, 1)
+	}
+	return 2, 2
 }
 
-// selection process: generate 1 selection per individual in the original population
-func (eng Engine) selection(offspringSize int, in <-chan gene.Population, out chan<- gene.Chromosome, chErr chan<- error) {
+// breeding process: for each generation, select the parents, mate them and mutate the children
+// The selection, crossover and mutation of a generation run in this single process: the random draws always happen
+// in the same order, so that a run is reproducible with random.Seed
+// The operators are prepared once per generation (see operator.Preparer)
+// The surplus children of the last crossover of a generation are discarded
+func (eng Engine) breeding(offspringSize int, tmr *timer, in <-chan gene.Population, out chan<- gene.Chromosome, chErr chan<- error) {
 	defer close(out)
+	nbParents, nbChildren := eng.arity()
+	nbGroups := (offspringSize + nbChildren - 1) / nbChildren
 	for population := range in {
-		for range offspringSize {
-			ind, err := eng.Selection.Select(population)
-			if err != nil {
-				chErr <- err
-				return
+		start := time.Now()
+		crossover := operator.Prepare(eng.CrossOver, population)
+		tmr.since(stageCrossOver, start)
+		start = time.Now()
+		mutation := operator.Prepare(eng.Mutation, population)
+		tmr.since(stageMutation, start)
+
+		parents, err := eng.selection(tmr, population, nbGroups*nbParents)
+		if err != nil {
+			chErr <- err
+			return
+		}
+
+		var sent int
+		for group := range nbGroups {
+			for _, child := range mate(tmr, crossover, parents[group*nbParents:(group+1)*nbParents], nbChildren) {
+				if sent < offspringSize {
+					out <- mutate(tmr, mutation, child)
+					sent++
+				}
 			}
-			out <- ind.Code
 		}
 	}
 }
 
-// crossover process: use 2 chromosomes and produce 2 new ones
-func (eng Engine) crossover(in <-chan gene.Chromosome, out chan<- gene.Chromosome) {
-	defer close(out)
-	for chrm1 := range in {
-		chrm2 := <-in
-		if eng.CrossOver != nil {
-			chrm1, chrm2 = eng.CrossOver.Mate(chrm1, chrm2)
+// selection selects the n parents of a generation
+// The selections only see the scaled fitness, the selection is prepared once (see operator.Preparer)
+func (eng Engine) selection(tmr *timer, population gene.Population, n int) ([]gene.Chromosome, error) {
+	start := time.Now()
+	defer tmr.since(stageSelection, start)
+	if eng.Scaling != nil {
+		population = population.Scaled()
+	}
+
+	// Select the whole mating pool at once
+	if sel, ok := eng.Selection.(operator.PoolSelection); ok {
+		pool, err := sel.SelectPool(population, n)
+		if err != nil {
+			return nil, err
 		}
-		out <- chrm1
-		out <- chrm2
+		parents := make([]gene.Chromosome, len(pool))
+		for i, ind := range pool {
+			parents[i] = ind.Code
+		}
+		return parents, nil
 	}
+
+	selection := operator.Prepare(eng.Selection, population)
+	parents := make([]gene.Chromosome, n)
+	for i := range parents {
+		ind, err := selection.Select(population)
+		if err != nil {
+			return nil, err
+		}
+		parents[i] = ind.Code
+	}
+	return parents, nil
 }
 
-// mutation process: mutate all chromosomes using the defined mutation function
-func (eng Engine) mutation(in <-chan gene.Chromosome, out chan<- gene.Chromosome) {
-	defer close(out)
-	for mut := range in {
-		if eng.Mutation != nil {
-			mut = eng.Mutation.Mutate(mut)
+// mate the parents and returns exactly nbChildren children (completed with the parents if needed)
+func mate(tmr *timer, crossover operator.CrossOver, parents []gene.Chromosome, nbChildren int) []gene.Chromosome {
+	start := time.Now()
+	var children []gene.Chromosome
+	switch co := crossover.(type) {
+	case nil:
+		children = parents
+	case operator.MultiParentCrossOver:
+		children = co.MateN(parents)
+	default:
+		chrm1, chrm2 := co.Mate(parents[0], parents[1])
+		children = []gene.Chromosome{chrm1, chrm2}
+	}
+	tmr.since(stageCrossOver, start)
+
+	for i := len(children); i < nbChildren; i++ {
+		children = append(children, parents[i%len(parents)])
+	}
+	return children[:nbChildren]
+}
+
+// mutate the chromosome (only if a mutation is defined)
+func mutate(tmr *timer, mutation operator.Mutation, chrm gene.Chromosome) gene.Chromosome {
+	if mutation == nil {
+		return chrm
+	}
+	start := time.Now()
+	defer tmr.since(stageMutation, start)
+	return mutation.Mutate(chrm)
+}
+
+// evaluator returns the fitness function and the optional case scores function as an evaluator
+func (eng Engine) evaluator() gene.Evaluator {
+	switch {
+	case eng.CaseFitness == nil:
+		return eng.Fitness.Evaluator()
+	case eng.Fitness == nil:
+		return eng.CaseFitness.Evaluator()
+	default:
+		return func(chrm gene.Chromosome) (float64, []float64) {
+			return eng.Fitness(chrm), eng.CaseFitness(chrm)
 		}
-		out <- mut
 	}
 }
 
-// fitness process: compute each individual fitness
-func (eng Engine) fitness(in <-chan gene.Chromosome, out chan<- gene.Individual) {
+// fitness process: compute each individual fitness (and its case scores)
+func (eng Engine) fitness(tmr *timer, in <-chan gene.Chromosome, out chan<- gene.Individual) {
 	defer close(out)
+	evaluator := eng.evaluator()
 	for chrm := range in {
-		fitness := eng.Fitness(chrm)
-		out <- gene.NewIndividual(chrm, fitness)
+		start := time.Now()
+		fitness, cases := evaluator(chrm)
+		tmr.since(stageFitness, start)
+		ind := gene.NewIndividual(chrm, fitness)
+		ind.Cases = cases
+		out <- ind
 	}
 }
 
@@ -211,13 +408,33 @@ func (eng Engine) offsprings(offspringSize int, in <-chan gene.Individual, out c
 	}
 }
 
+// scale the fitness of the population (only if a scaling is defined), the totals shall be computed next
+func (eng Engine) scale(pop *gene.Population) {
+	if eng.Scaling != nil {
+		pop.Scale(eng.Scaling.Scale(*pop))
+	}
+}
+
 // Survivors builds a new population of individuals
 // The new population has changed, so compute global data like total fitness
-func (eng Engine) survivors(start time.Time, parents gene.Population, offsprings gene.Population) gene.Population {
-	newPop := eng.Survivor.Survive(parents, offsprings)
+func (eng Engine) survivors(start time.Time, tmr *timer, parents gene.Population, offsprings gene.Population) gene.Population {
+	startSurvivor := time.Now()
+
+	// The new individuals of the survivor (if any) are evaluated like the offsprings
+	var evaluations int
+	evaluator := eng.evaluator()
+	survivor := operator.WithEvaluator(eng.Survivor, func(chrm gene.Chromosome) (float64, []float64) {
+		evaluations++
+		return evaluator(chrm)
+	})
+	newPop := survivor.Survive(parents, offsprings)
+	eng.scale(&newPop)
 	newPop.ComputeTotalFitness()
 	newPop.ComputeRank()
 	newPop.Stats.GenerationNb = parents.Stats.GenerationNb + 1
+	newPop.Stats.Evaluations = parents.Stats.Evaluations + offsprings.Len() + evaluations
+	newPop.Stats.Stages = tmr.reset()
+	newPop.Stats.Stages.Survivor = time.Since(startSurvivor)
 	newPop.Stats.TotalDuration = time.Since(start)
 	return newPop
 }
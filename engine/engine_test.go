@@ -1,13 +1,60 @@
 package engine
 
 import (
+	"slices"
+	"sync/atomic"
 	"testing"
 
 	"github.com/sbiemont/galgogene/gene"
 	"github.com/sbiemont/galgogene/operator"
+	"github.com/sbiemont/galgogene/random"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+// countingSelection counts the number of selections
+type countingSelection struct {
+	operator.Selection
+	count *atomic.Int64
+}
+
+func (sel countingSelection) Select(pop gene.Population) (gene.Individual, error) {
+	sel.count.Add(1)
+	return sel.Selection.Select(pop)
+}
+
+// preparedSelection counts the number of preparations of the selection
+type preparedSelection struct {
+	operator.Selection
+	count *atomic.Int64
+}
+
+func (sel preparedSelection) Prepare(pop gene.Population) operator.Selection {
+	sel.count.Add(1)
+	return operator.Prepare(sel.Selection, pop)
+}
+
+// preparedCrossOver counts the number of preparations of the crossover
+type preparedCrossOver struct {
+	operator.CrossOver
+	count *atomic.Int64
+}
+
+func (co preparedCrossOver) Prepare(gene.Population) operator.CrossOver {
+	co.count.Add(1)
+	return co.CrossOver
+}
+
+// preparedMutation counts the number of preparations of the mutation
+type preparedMutation struct {
+	operator.Mutation
+	count *atomic.Int64
+}
+
+func (mut preparedMutation) Prepare(gene.Population) operator.Mutation {
+	mut.count.Add(1)
+	return mut.Mutation
+}
+
 func TestEngine(t *testing.T) {
 	Convey("check", t, func() {
 		Convey("when missing fitness", func() {
@@ -32,5 +79,376 @@ func TestEngine(t *testing.T) {
 			}
 			So(eng.check(), ShouldBeNil)
 		})
+
+		Convey("when genome", func() {
+			eng := Engine{
+				Initializer: gene.GenomeInitializer{},
+				Selection:   operator.RouletteSelection{},
+				CrossOver:   operator.OnePointCrossOver{},
+				Survivor:    operator.RankSurvivor{},
+				Termination: &operator.DurationTermination{},
+				Fitness:     func(c gene.Chromosome) float64 { return 0 },
+			}
+			So(eng.check(), ShouldBeError, "crossover must be a genome crossover for a genome initializer")
+
+			eng.CrossOver = operator.GenomeCrossOver{}
+			So(eng.check(), ShouldBeNil)
+			eng.Mutation = operator.UniqueMutation{}
+			So(eng.check(), ShouldBeError, "mutation must be a genome mutation for a genome initializer")
+			eng.Mutation = operator.GenomeMutation{}
+			So(eng.check(), ShouldBeNil)
+		})
+	})
+
+	Convey("run", t, func() {
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   operator.TournamentSelection{Fighters: 2},
+			CrossOver:   operator.OnePointCrossOver{},
+			Mutation:    operator.UniqueMutation{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 5},
+			Fitness: func(c gene.Chromosome) float64 {
+				var fitness float64
+				for _, b := range c.Raw {
+					fitness += float64(b)
+				}
+				return fitness
+			},
+		}
+
+		var generations []gene.Population
+		eng.OnNewGeneration = func(pop, _, _ gene.Population) {
+			generations = append(generations, pop)
+		}
+		sol, err := eng.Run(10, 20, 8)
+		So(err, ShouldBeNil)
+		So(sol.TerminationType(&operator.GenerationTermination{}), ShouldBeTrue)
+		So(generations, ShouldHaveLength, 6)
+		for i, pop := range generations {
+			So(pop.Stats.GenerationNb, ShouldEqual, i)
+			So(pop.Stats.Evaluations, ShouldEqual, 10+20*i) // initial population + offsprings
+			if i > 0 {
+				So(pop.Stats.Stages.Fitness, ShouldBeGreaterThan, 0)
+				So(pop.Stats.Stages.Survivor, ShouldBeGreaterThan, 0)
+			}
+		}
+	})
+	Convey("run with seeds", t, func() {
+		seed := gene.NewChromosome(8, 1)
+		copy(seed.Raw, []gene.B{1, 1, 1, 1, 1, 1, 1, 1})
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   operator.EliteSelection{},
+			CrossOver:   operator.OnePointCrossOver{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 1},
+			Fitness: func(c gene.Chromosome) float64 {
+				return float64(c.Raw[0] & c.Raw[1] & c.Raw[2] & c.Raw[3] & c.Raw[4] & c.Raw[5] & c.Raw[6] & c.Raw[7])
+			},
+			Seeds: []gene.Chromosome{seed},
+		}
+
+		var initial gene.Population
+		eng.OnNewGeneration = func(pop, _, _ gene.Population) {
+			if pop.Stats.GenerationNb == 0 {
+				initial = pop
+			}
+		}
+		sol, err := eng.Run(10, 10, 8)
+		So(err, ShouldBeNil)
+		So(initial.Individuals[0].Code.Raw, ShouldResemble, seed.Raw)
+		So(sol.PopWithBestIndividual.Elite().Fitness, ShouldEqual, 1)
+
+		Convey("when invalid seed", func() {
+			seed.Raw[0] = 2
+			_, err := eng.Run(10, 10, 8)
+			So(err, ShouldBeError, "seed #0: base #0 (2) shall be <= 1")
+		})
+	})
+	Convey("run with variable-length chromosomes", t, func() {
+		eng := Engine{
+			Initializer: gene.VariableInitializer{MinLen: 2, MaxLen: 10, MaxValue: 1},
+			Selection:   operator.TournamentSelection{Fighters: 2},
+			CrossOver:   operator.CutAndSpliceCrossOver{MinLen: 2, MaxLen: 10},
+			Mutation: operator.MultiMutation{}.
+				Use(0.3, operator.InsertMutation{MaxLen: 10}).
+				Use(0.3, operator.DeleteMutation{MinLen: 2}).
+				Use(0.3, operator.DuplicateMutation{MaxLen: 10}),
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 20},
+			Fitness: func(c gene.Chromosome) float64 {
+				return float64(c.Len())
+			},
+		}
+
+		var lengths []int
+		eng.OnNewGeneration = func(pop, _, _ gene.Population) {
+			for _, ind := range pop.Individuals {
+				lengths = append(lengths, ind.Code.Len())
+			}
+		}
+		sol, err := eng.Run(10, 10, 0)
+		So(err, ShouldBeNil)
+		So(slices.Min(lengths), ShouldBeGreaterThanOrEqualTo, 2)
+		So(slices.Max(lengths), ShouldBeLessThanOrEqualTo, 10)
+		So(sol.PopWithBestIndividual.Elite().Fitness, ShouldEqual, 10)
+	})
+	Convey("run with genomes", t, func() {
+		eng := Engine{
+			Initializer: gene.GenomeInitializer{
+				Parts: []gene.GenomePart{
+					{Name: "jobs", Size: 6, Initializer: gene.PermutationInitializer{}},
+					{Name: "machines", Size: 6, Initializer: gene.RandomInitializer{MaxValue: 2}},
+				},
+			},
+			Selection: operator.TournamentSelection{Fighters: 2},
+			CrossOver: operator.GenomeCrossOver{}.
+				Use("jobs", operator.DavisOrderCrossOver{}).
+				Use("machines", operator.UniformCrossOver{}),
+			Mutation: operator.GenomeMutation{}.
+				Use("jobs", operator.SwapPermutation{}).
+				Use("machines", operator.UniqueMutation{}),
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 20},
+			Fitness: gene.GenomeFitness(func(gnm gene.Genome) float64 {
+				// Each job i shall run on machine i%3
+				jobs, _ := gnm.Get("jobs")
+				machines, _ := gnm.Get("machines")
+				var score float64
+				for i, job := range jobs.Raw {
+					if int(machines.Raw[i]) == int(job)%3 {
+						score++
+					}
+				}
+				return score / 6
+			}),
+		}
+
+		sol, err := eng.Run(20, 20, 0)
+		So(err, ShouldBeNil)
+		elite := sol.PopWithBestIndividual.Elite()
+		So(elite.Genome().Names, ShouldResemble, []string{"jobs", "machines"})
+		So(eng.Initializer.(gene.GenomeInitializer).Validate(elite.Code), ShouldBeNil)
+		So(elite.Fitness, ShouldBeGreaterThan, 0.5)
+	})
+	Convey("run with a multi-parent crossover", t, func() {
+		var count atomic.Int64
+		eng := Engine{
+			Initializer: gene.PermutationInitializer{},
+			Selection:   countingSelection{Selection: operator.TournamentSelection{Fighters: 2}, count: &count},
+			CrossOver:   operator.MultiParentOrderCrossOver{Parents: 3},
+			Mutation:    operator.SwapPermutation{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 5},
+			Fitness: func(c gene.Chromosome) float64 {
+				return float64(c.Raw[0])
+			},
+		}
+
+		var sizes []int
+		var errs []error
+		eng.OnNewGeneration = func(pop, _, _ gene.Population) {
+			sizes = append(sizes, pop.Len())
+			for _, ind := range pop.Individuals {
+				if err := (gene.PermutationInitializer{}).Validate(ind.Code); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		_, err := eng.Run(10, 10, 8)
+		So(err, ShouldBeNil)
+		So(sizes, ShouldResemble, []int{10, 10, 10, 10, 10, 10}) // initial population included
+		So(errs, ShouldBeEmpty)
+		So(count.Load(), ShouldEqual, 5*12) // 4 crossovers of 3 parents per generation, 2 children are discarded
+	})
+
+	Convey("run with a one-child crossover", t, func() {
+		var count atomic.Int64
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   countingSelection{Selection: operator.TournamentSelection{Fighters: 2}, count: &count},
+			CrossOver:   operator.ScanningCrossOver{Parents: 4, Occurrence: true},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 3},
+			Fitness: func(c gene.Chromosome) float64 {
+				return float64(c.Raw[0])
+			},
+		}
+		_, err := eng.Run(6, 6, 8)
+		So(err, ShouldBeNil)
+		So(count.Load(), ShouldEqual, 3*6*4)
+	})
+
+	Convey("run with a pool selection", t, func() {
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   operator.StochasticUniversalSampling{},
+			CrossOver:   operator.OnePointCrossOver{},
+			Mutation:    operator.UniqueMutation{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 10},
+			Fitness: func(c gene.Chromosome) float64 {
+				return 1 + float64(c.Distance(c.New()))
+			},
+		}
+		sol, err := eng.Run(20, 30, 16)
+		So(err, ShouldBeNil)
+		So(sol.PopWithBestIndividual.Len(), ShouldEqual, 20)
+	})
+
+	Convey("run with prepared operators", t, func() {
+		var nbSel, nbCo, nbMut atomic.Int64
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   preparedSelection{Selection: operator.RouletteSelection{}, count: &nbSel},
+			CrossOver:   preparedCrossOver{CrossOver: operator.OnePointCrossOver{}, count: &nbCo},
+			Mutation:    preparedMutation{Mutation: operator.UniqueMutation{}, count: &nbMut},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 10},
+			Fitness: func(c gene.Chromosome) float64 {
+				return 1 + float64(c.Distance(c.New()))
+			},
+		}
+		sol, err := eng.Run(20, 30, 16)
+		So(err, ShouldBeNil)
+		So(sol.PopWithBestIndividual.Len(), ShouldEqual, 20)
+
+		// Once per generation
+		So(nbSel.Load(), ShouldEqual, 10)
+		So(nbCo.Load(), ShouldEqual, 10)
+		So(nbMut.Load(), ShouldEqual, 10)
+	})
+
+	Convey("run with case scores", t, func() {
+		// Each case is a bit to be set
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   operator.EpsilonLexicaseSelection{},
+			CrossOver:   operator.OnePointCrossOver{},
+			Mutation:    operator.UniqueMutation{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 10},
+			CaseFitness: func(c gene.Chromosome) []float64 {
+				cases := make([]float64, c.Len())
+				for i, b := range c.Raw {
+					cases[i] = float64(b)
+				}
+				return cases
+			},
+		}
+		sol, err := eng.Run(20, 20, 8)
+		So(err, ShouldBeNil)
+		for _, ind := range sol.PopWithBestIndividual.Individuals {
+			So(ind.Cases, ShouldHaveLength, 8)
+			So(ind.Fitness, ShouldEqual, float64(ind.Code.Distance(ind.Code.New())))
+		}
+
+		Convey("when the survivor builds new individuals", func() {
+			// Identical initial chromosomes: the duplicates are replaced
+			eng.Survivor = operator.UniqueSurvivor{Initializer: eng.Initializer, Size: 8}
+			eng.Selection = operator.LexicaseSelection{}
+			eng.Seeds = make([]gene.Chromosome, 20)
+			for i := range eng.Seeds {
+				eng.Seeds[i] = gene.NewChromosome(8, 1)
+			}
+			var evaluations []int
+			eng.OnNewGeneration = func(pop, _, _ gene.Population) {
+				evaluations = append(evaluations, pop.Stats.Evaluations)
+			}
+			sol, err := eng.Run(20, 20, 8)
+			So(err, ShouldBeNil)
+			for _, ind := range sol.PopWithBestIndividual.Individuals {
+				So(ind.Cases, ShouldHaveLength, 8)
+			}
+			So(evaluations[0], ShouldEqual, 20)
+			So(evaluations[1], ShouldBeGreaterThan, 20+20)
+		})
+	})
+
+	Convey("run with a scaling", t, func() {
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   operator.RouletteSelection{},
+			Scaling:     operator.RankScaling{},
+			CrossOver:   operator.OnePointCrossOver{},
+			Mutation:    operator.UniqueMutation{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 5},
+			Fitness: func(c gene.Chromosome) float64 {
+				return 10 + float64(c.Distance(c.New()))
+			},
+		}
+
+		var totals, scaledTotals []float64
+		eng.OnNewGeneration = func(pop, _, _ gene.Population) {
+			var total float64
+			for _, ind := range pop.Individuals {
+				total += ind.Fitness
+			}
+			totals = append(totals, pop.Stats.TotalFitness-total)
+			scaledTotals = append(scaledTotals, pop.Stats.TotalScaledFitness)
+		}
+		_, err := eng.Run(20, 20, 16)
+		So(err, ShouldBeNil)
+		So(totals, ShouldResemble, []float64{0, 0, 0, 0, 0, 0}) // raw fitness kept
+		So(scaledTotals, ShouldHaveLength, 6)
+		for _, total := range scaledTotals {
+			So(total, ShouldAlmostEqual, 10.5, 1e-9) // sum of (rank+1)/20
+		}
+	})
+
+	Convey("run with a seed", t, func() {
+		run := func() []string {
+			random.Seed(7)
+			eng := Engine{
+				Initializer: gene.PermutationInitializer{},
+				Selection:   operator.RouletteSelection{},
+				CrossOver:   operator.MultiCrossOver{}.Use(0.5, operator.UniformOrderCrossOver{}).Use(1, operator.DavisOrderCrossOver{}),
+				Mutation:    operator.MultiMutation{}.Use(0.5, operator.SwapPermutation{}).Use(0.5, operator.ScramblePermutation{}),
+				Survivor:    operator.MultiSurvivor{}.Use(0.5, operator.EliteSurvivor{}).Otherwise(operator.RandomSurvivor{}),
+				Termination: &operator.GenerationTermination{K: 20},
+				Fitness: func(c gene.Chromosome) float64 {
+					var fitness float64
+					for i, b := range c.Raw {
+						fitness += float64(i * int(b))
+					}
+					return fitness
+				},
+			}
+			var history []string
+			eng.OnNewGeneration = func(pop, _, _ gene.Population) {
+				for _, ind := range pop.Individuals {
+					history = append(history, ind.Code.String())
+				}
+			}
+			_, err := eng.Run(20, 20, 10)
+			So(err, ShouldBeNil)
+			return history
+		}
+
+		// Same seed, same run
+		So(run(), ShouldResemble, run())
+	})
+
+	Convey("run with packed chromosomes", t, func() {
+		eng := Engine{
+			Initializer: gene.BitInitializer{},
+			Selection:   operator.TournamentSelection{Fighters: 3},
+			CrossOver:   operator.BitUniformCrossOver{},
+			Mutation:    operator.BitFlipMutation{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 10},
+			Fitness: func(c gene.Chromosome) float64 {
+				return float64(c.Bits.Count()) / float64(c.Len())
+			},
+		}
+
+		sol, err := eng.Run(20, 20, 1000)
+		So(err, ShouldBeNil)
+		elite := sol.PopWithBestIndividual.Elite()
+		So(elite.Code.IsPacked(), ShouldBeTrue)
+		So(elite.Code.Len(), ShouldEqual, 1000)
+		So(elite.Fitness, ShouldBeGreaterThan, 0.5)
 	})
 }
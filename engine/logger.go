@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/sbiemont/galgogene/gene"
+)
+
+// Logging configures the structured logs of a run
+// * run start (with the configuration): info level
+// * each generation stats: configurable level, every n generations
+// * stagnation (see engine Stagnation): info level
+// * restart (see engine Restart): info level
+// * termination (with the triggering operator): info level
+// * errors: error level
+type Logging struct {
+	Logger *slog.Logger // Logger to be used (no logs if nil)
+	Level  slog.Level   // Level of the generation logs (default: info)
+	Every  int          // Log the generation stats every n generations (default: 1)
+}
+
+// logger writes the run events using the logging configuration
+type logger struct {
+	Logging
+}
+
+func (lgr logger) enabled() bool {
+	return lgr.Logger != nil
+}
+
+// start logs the run configuration
+func (lgr logger) start(eng Engine, popSize, offspringSize, chromosomeSize int) {
+	if !lgr.enabled() {
+		return
+	}
+	lgr.Logger.LogAttrs(
+		context.Background(),
+		slog.LevelInfo,
+		"run start",
+		slog.Int("popSize", popSize),
+		slog.Int("offspringSize", offspringSize),
+		slog.Int("chromosomeSize", chromosomeSize),
+		slog.String("initializer", typeName(eng.Initializer)),
+		slog.String("selection", typeName(eng.Selection)),
+		slog.String("crossover", typeName(eng.CrossOver)),
+		slog.String("mutation", typeName(eng.Mutation)),
+		slog.String("survivor", typeName(eng.Survivor)),
+		slog.String("termination", typeName(eng.Termination)),
+	)
+}
+
+// generation logs the population stats (only every n generations)
+func (lgr logger) generation(pop gene.Population) {
+	if !lgr.enabled() || pop.Stats.GenerationNb%max(lgr.Every, 1) != 0 {
+		return
+	}
+	lgr.Logger.LogAttrs(
+		context.Background(),
+		lgr.Level,
+		"generation",
+		slog.Int("generation", pop.Stats.GenerationNb),
+		slog.Duration("duration", pop.Stats.TotalDuration),
+		slog.Float64("eliteFitness", pop.Elite().Fitness),
+		slog.Float64("meanFitness", pop.Stats.TotalFitness/float64(pop.Len())),
+		slog.Float64("totalFitness", pop.Stats.TotalFitness),
+		slog.Int("evaluations", pop.Stats.Evaluations),
+	)
+}
+
+// termination logs the ending condition and the best individual
+func (lgr logger) termination(pop gene.Population, sol Solution) {
+	if !lgr.enabled() {
+		return
+	}
+	lgr.Logger.LogAttrs(
+		context.Background(),
+		slog.LevelInfo,
+		"termination",
+		slog.String("termination", typeName(sol.Termination)),
+		slog.Int("generation", pop.Stats.GenerationNb),
+		slog.Duration("duration", pop.Stats.TotalDuration),
+		slog.Float64("bestFitness", sol.PopWithBestIndividual.Elite().Fitness),
+		slog.Int("bestGeneration", sol.PopWithBestIndividual.Stats.GenerationNb),
+		slog.Float64("bestTotalFitness", sol.PopWithBestTotalFitness.Stats.TotalFitness),
+	)
+}
+
+// stagnation logs the lack of improvement of the best individual
+func (lgr logger) stagnation(pop, withBestIndividual gene.Population) {
+	if !lgr.enabled() {
+		return
+	}
+	lgr.Logger.LogAttrs(
+		context.Background(),
+		slog.LevelInfo,
+		"stagnation",
+		slog.Int("generation", pop.Stats.GenerationNb),
+		slog.Float64("bestFitness", withBestIndividual.Elite().Fitness),
+		slog.Int("bestGeneration", withBestIndividual.Stats.GenerationNb),
+	)
+}
+
+// restart logs the new initial population built after a stagnation
+func (lgr logger) restart(pop gene.Population) {
+	if !lgr.enabled() {
+		return
+	}
+	lgr.Logger.LogAttrs(
+		context.Background(),
+		slog.LevelInfo,
+		"restart",
+		slog.Int("generation", pop.Stats.GenerationNb),
+		slog.Float64("eliteFitness", pop.Elite().Fitness),
+		slog.Float64("meanFitness", pop.Stats.TotalFitness/float64(pop.Len())),
+	)
+}
+
+// error logs the error that stopped the run
+func (lgr logger) error(err error) {
+	if !lgr.enabled() {
+		return
+	}
+	lgr.Logger.LogAttrs(context.Background(), slog.LevelError, "run error", slog.String("error", err.Error()))
+}
+
+// typeName returns the operator type name (empty if not defined)
+func typeName(operator any) string {
+	if operator == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", operator)
+}
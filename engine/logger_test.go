@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// readLogs decodes each json log line
+func readLogs(buf *bytes.Buffer) []map[string]any {
+	var logs []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var log map[string]any
+		So(dec.Decode(&log), ShouldBeNil)
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+// errSelection always fails
+type errSelection struct{}
+
+func (errSelection) Select(gene.Population) (gene.Individual, error) {
+	return gene.Individual{}, errors.New("selection error")
+}
+
+func TestLogger(t *testing.T) {
+	newEngine := func(buf *bytes.Buffer, every int) Engine {
+		return Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   operator.TournamentSelection{Fighters: 2},
+			CrossOver:   operator.OnePointCrossOver{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 4},
+			Fitness:     func(c gene.Chromosome) float64 { return 1 },
+			Logging: Logging{
+				Logger: slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+				Level:  slog.LevelDebug,
+				Every:  every,
+			},
+		}
+	}
+
+	Convey("run", t, func() {
+		var buf bytes.Buffer
+		_, err := newEngine(&buf, 2).Run(10, 10, 8)
+		So(err, ShouldBeNil)
+
+		logs := readLogs(&buf)
+		So(logs, ShouldHaveLength, 5)
+
+		// Start
+		So(logs[0]["msg"], ShouldEqual, "run start")
+		So(logs[0]["level"], ShouldEqual, "INFO")
+		So(logs[0]["popSize"], ShouldEqual, 10)
+		So(logs[0]["chromosomeSize"], ShouldEqual, 8)
+		So(logs[0]["selection"], ShouldEqual, "operator.TournamentSelection")
+		So(logs[0]["mutation"], ShouldEqual, "")
+
+		// Sampled generations
+		for i, generation := range []float64{0, 2, 4} {
+			So(logs[i+1]["msg"], ShouldEqual, "generation")
+			So(logs[i+1]["level"], ShouldEqual, "DEBUG")
+			So(logs[i+1]["generation"], ShouldEqual, generation)
+			So(logs[i+1]["meanFitness"], ShouldEqual, 1)
+		}
+
+		// Termination
+		So(logs[4]["msg"], ShouldEqual, "termination")
+		So(logs[4]["termination"], ShouldEqual, "*operator.GenerationTermination")
+		So(logs[4]["generation"], ShouldEqual, 4)
+		So(logs[4]["bestFitness"], ShouldEqual, 1)
+	})
+
+	Convey("restart", t, func() {
+		var buf bytes.Buffer
+		eng := newEngine(&buf, 4)
+		eng.Stagnation = 3
+		eng.Restart = true
+		_, err := eng.Run(10, 10, 8)
+		So(err, ShouldBeNil)
+
+		logs := readLogs(&buf)
+		So(logs, ShouldHaveLength, 6)
+		So(logs[2]["msg"], ShouldEqual, "stagnation")
+		So(logs[3]["msg"], ShouldEqual, "restart")
+		So(logs[3]["level"], ShouldEqual, "INFO")
+		So(logs[3]["generation"], ShouldEqual, 3)
+		So(logs[3]["meanFitness"], ShouldEqual, 1)
+	})
+
+	Convey("error", t, func() {
+		var buf bytes.Buffer
+		eng := newEngine(&buf, 0)
+		eng.Selection = errSelection{}
+		_, err := eng.Run(10, 10, 8)
+		So(err, ShouldBeError, "selection error")
+
+		logs := readLogs(&buf)
+		So(logs, ShouldHaveLength, 3)
+		So(logs[1]["generation"], ShouldEqual, 0)
+		So(logs[2]["msg"], ShouldEqual, "run error")
+		So(logs[2]["level"], ShouldEqual, "ERROR")
+		So(logs[2]["error"], ShouldEqual, "selection error")
+	})
+
+	Convey("without logger", t, func() {
+		So(func() { logger{}.error(errors.New("no logs")) }, ShouldNotPanic)
+	})
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+)
+
+// EventKind is the type of event raised during a run
+type EventKind int
+
+const (
+	EventStart             EventKind = iota // Run start (no population yet)
+	EventInitialPopulation                  // Initial population ready
+	EventGeneration                         // New generation done
+	EventNewBest                            // New best individual found
+	EventStagnation                         // No improvement of the best individual during n generations
+	EventRestart                            // New initial population after a stagnation (see engine Restart)
+	EventTermination                        // Ending condition raised
+)
+
+var eventKinds = [...]string{"start", "initial population", "generation", "new best", "stagnation", "restart", "termination"}
+
+func (kind EventKind) String() string {
+	if kind < 0 || int(kind) >= len(eventKinds) {
+		return "unknown"
+	}
+	return eventKinds[kind]
+}
+
+// Event of the run lifecycle
+type Event struct {
+	Kind                 EventKind
+	Population           gene.Population      // Current population
+	WithBestIndividual   gene.Population      // Population with the best individual
+	WithBestTotalFitness gene.Population      // Population with the best total fitness
+	Termination          operator.Termination // Ending condition raised (termination event only)
+}
+
+// Observer is notified of each event of a run
+// Notify is called synchronously by the engine: long processing shall be done asynchronously
+type Observer interface {
+	Notify(evt Event)
+}
+
+// ObserverFunc is a function used as an observer
+type ObserverFunc func(evt Event)
+
+func (fn ObserverFunc) Notify(evt Event) {
+	fn(evt)
+}
+
+// stagnation counts the generations without improvement of the best individual
+type stagnation struct {
+	k     int // Number of generations to be reached (0: never)
+	count int // Current number of generations without improvement
+}
+
+// next counts a new generation and returns true when the stagnation is detected
+// The counter restarts after each detection
+func (stg *stagnation) next(improved bool) bool {
+	if improved || stg.k <= 0 {
+		stg.count = 0
+		return false
+	}
+	stg.count++
+	if stg.count < stg.k {
+		return false
+	}
+	stg.count = 0
+	return true
+}
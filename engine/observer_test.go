@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestObserver(t *testing.T) {
+	Convey("event kind", t, func() {
+		So(EventStart.String(), ShouldEqual, "start")
+		So(EventTermination.String(), ShouldEqual, "termination")
+		So(EventKind(42).String(), ShouldEqual, "unknown")
+	})
+
+	Convey("stagnation", t, func() {
+		stg := stagnation{k: 2}
+		So(stg.next(false), ShouldBeFalse)
+		So(stg.next(true), ShouldBeFalse)
+		So(stg.next(false), ShouldBeFalse)
+		So(stg.next(false), ShouldBeTrue)
+		So(stg.next(false), ShouldBeFalse)
+		So(stg.next(false), ShouldBeTrue)
+
+		never := stagnation{}
+		So(never.next(false), ShouldBeFalse)
+	})
+
+	Convey("run", t, func() {
+		// Fitness only improved at generation #3
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   operator.TournamentSelection{Fighters: 2},
+			CrossOver:   operator.OnePointCrossOver{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 6},
+			Stagnation:  3,
+		}
+		var evaluations int
+		eng.Fitness = func(gene.Chromosome) float64 {
+			evaluations++
+			if evaluations > 2*10+10 { // initial population, generations #1 and #2
+				return 1
+			}
+			return 0.5
+		}
+
+		var kinds1, kinds2 []EventKind
+		var termination operator.Termination
+		eng.Observers = []Observer{
+			ObserverFunc(func(evt Event) {
+				kinds1 = append(kinds1, evt.Kind)
+				if evt.Kind == EventTermination {
+					termination = evt.Termination
+				}
+			}),
+			ObserverFunc(func(evt Event) {
+				kinds2 = append(kinds2, evt.Kind)
+			}),
+		}
+		_, err := eng.Run(10, 10, 4)
+		So(err, ShouldBeNil)
+		So(kinds1, ShouldResemble, []EventKind{
+			EventStart,
+			EventInitialPopulation,
+			EventGeneration,
+			EventGeneration,
+			EventGeneration, EventNewBest, // #3: first improvement
+			EventGeneration,
+			EventGeneration,
+			EventGeneration, EventStagnation, // #6: no improvement since #3
+			EventTermination,
+		})
+		So(kinds2, ShouldResemble, kinds1)
+		So(termination, ShouldHaveSameTypeAs, &operator.GenerationTermination{})
+	})
+
+	Convey("restart", t, func() {
+		// Fitness never improved after the initial population
+		eng := Engine{
+			Initializer: gene.RandomInitializer{MaxValue: 1},
+			Selection:   operator.TournamentSelection{Fighters: 2},
+			CrossOver:   operator.OnePointCrossOver{},
+			Survivor:    operator.EliteSurvivor{},
+			Termination: &operator.GenerationTermination{K: 4},
+			Fitness:     func(gene.Chromosome) float64 { return 1 },
+			Stagnation:  2,
+			Restart:     true,
+		}
+		var kinds []EventKind
+		var restarted gene.Population
+		var elite gene.Individual
+		eng.Observers = []Observer{
+			ObserverFunc(func(evt Event) {
+				kinds = append(kinds, evt.Kind)
+				if evt.Kind == EventRestart && restarted.Len() == 0 {
+					restarted = evt.Population
+					elite = evt.WithBestIndividual.Elite()
+				}
+			}),
+		}
+		sol, err := eng.Run(10, 10, 4)
+		So(err, ShouldBeNil)
+		So(kinds, ShouldResemble, []EventKind{
+			EventStart,
+			EventInitialPopulation,
+			EventGeneration,
+			EventGeneration, EventStagnation, EventRestart, // #2
+			EventGeneration,
+			EventGeneration, EventStagnation, EventRestart, // #4
+			EventTermination,
+		})
+
+		// New population with the best individual, the generations go on
+		So(restarted.Len(), ShouldEqual, 10)
+		So(restarted.Individuals[0].Code, ShouldResemble, elite.Code)
+		So(restarted.Stats.GenerationNb, ShouldEqual, 2)
+		So(restarted.Stats.Evaluations, ShouldEqual, 10+2*10+10)
+		So(sol.PopWithBestIndividual.Elite().Fitness, ShouldEqual, 1)
+	})
+}
@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/sbiemont/galgogene/gene"
+)
+
+// stage of the engine processing
+type stage int
+
+const (
+	stageSelection stage = iota
+	stageCrossOver
+	stageMutation
+	stageFitness
+	nbStages
+)
+
+// timer accumulates the time spent in each stage, it is shared by all stages processes
+type timer [nbStages]atomic.Int64
+
+// since adds the time elapsed since start to the stage
+func (tmr *timer) since(stg stage, start time.Time) {
+	tmr[stg].Add(int64(time.Since(start)))
+}
+
+// reset returns the accumulated durations and restarts all counters
+func (tmr *timer) reset() gene.StageDurations {
+	return gene.StageDurations{
+		Selection: time.Duration(tmr[stageSelection].Swap(0)),
+		CrossOver: time.Duration(tmr[stageCrossOver].Swap(0)),
+		Mutation:  time.Duration(tmr[stageMutation].Swap(0)),
+		Fitness:   time.Duration(tmr[stageFitness].Swap(0)),
+	}
+}
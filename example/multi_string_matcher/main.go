@@ -27,7 +27,7 @@ func main() {
 	}
 
 	eng := engine.Engine{
-		Initializer: gene.NewRandomInitializer(255),
+		Initializer: gene.SpecInitializer{Spec: gene.UniformSpec(gene.Printable)},
 		Selection: operator.MultiSelection{}.
 			Use(0.5, operator.TournamentSelection{Fighters: 10}). // 50% chance to use tournament
 			Use(0.5, operator.EliteSelection{}).                  // 50% chance to use elite
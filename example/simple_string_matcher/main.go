@@ -26,7 +26,7 @@ func main() {
 
 	// Engine will stop when max fitness is reached
 	eng := engine.Engine{
-		Initializer: gene.NewRandomInitializer(255),
+		Initializer: gene.SpecInitializer{Spec: gene.UniformSpec(gene.Printable)},
 		Selection:   operator.TournamentSelection{Fighters: 6},
 		CrossOver:   operator.ThreePointsCrossOver{},
 		Mutation:    operator.UniqueMutation{},
@@ -139,9 +139,12 @@ func run(game *Game, dc DataCsv) {
 			Use(0.06, operator.InversionPermutation{}).
 			Use(0.05, operator.SwapPermutation{}).
 			Use(0.05, operator.ScramblePermutation{}),
-		Survivor: operator.MultiSurvivor{}.
-			Use(0.6, operator.EliteSurvivor{}).
-			Otherwise(operator.RandomSurvivor{}),
+		Survivor: operator.UniqueSurvivor{ // replace identical tours by mutated copies
+			Survivor: operator.MultiSurvivor{}.
+				Use(0.6, operator.EliteSurvivor{}).
+				Otherwise(operator.RandomSurvivor{}),
+			Mutation: operator.InversionPermutation{},
+		},
 		Termination: operator.MultiTermination{}.
 			Use(&operator.GenerationTermination{K: 1500}).
 			Use(&operator.ImprovementTermination{K: 2 * 100}).
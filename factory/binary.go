@@ -0,0 +1,61 @@
+package factory
+
+import (
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+)
+
+// Binary factory for genes with packed bits
+type Binary struct {
+	Initializer binaryInitializer
+	Selection   commonSelection
+	Survivor    commonSurvivor
+	Mutation    binaryMutation
+	CrossOver   binaryCrossOver
+	Termination commonTermination
+	Scaling     commonScaling
+}
+
+// Initializer
+
+type binaryInitializer struct{}
+
+func (f binaryInitializer) Bits() gene.BitInitializer {
+	return gene.BitInitializer{}
+}
+
+// CrossOver
+
+type binaryCrossOver struct{}
+
+func (f binaryCrossOver) OnePoint() operator.BitOnePointCrossOver {
+	return operator.BitOnePointCrossOver{}
+}
+
+func (f binaryCrossOver) TwoPoints() operator.BitTwoPointsCrossOver {
+	return operator.BitTwoPointsCrossOver{}
+}
+
+func (f binaryCrossOver) Uniform() operator.BitUniformCrossOver {
+	return operator.BitUniformCrossOver{}
+}
+
+func (f binaryCrossOver) Multi() operator.MultiCrossOver {
+	return operator.MultiCrossOver{}
+}
+
+// Mutation
+
+type binaryMutation struct{}
+
+func (f binaryMutation) Flip(rate float64) operator.BitFlipMutation {
+	return operator.BitFlipMutation{Rate: rate}
+}
+
+func (f binaryMutation) Uniform() operator.BitUniformMutation {
+	return operator.BitUniformMutation{}
+}
+
+func (f binaryMutation) Multi() operator.MultiMutation {
+	return operator.MultiMutation{}
+}
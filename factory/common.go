@@ -20,10 +20,45 @@ func (f commonSelection) Tournament(fighters int) operator.TournamentSelection {
 	}
 }
 
+func (f commonSelection) AdaptiveTournament(minFighters, maxFighters int) operator.AdaptiveTournamentSelection {
+	return operator.AdaptiveTournamentSelection{
+		Min: minFighters,
+		Max: maxFighters,
+	}
+}
+
 func (f commonSelection) Elite() operator.EliteSelection {
 	return operator.EliteSelection{}
 }
 
+func (f commonSelection) Rank(pressure float64) operator.RankSelection {
+	return operator.RankSelection{Pressure: pressure}
+}
+
+func (f commonSelection) ExponentialRank(base float64) operator.ExponentialRankSelection {
+	return operator.ExponentialRankSelection{Base: base}
+}
+
+func (f commonSelection) StochasticUniversalSampling() operator.StochasticUniversalSampling {
+	return operator.StochasticUniversalSampling{}
+}
+
+func (f commonSelection) Truncation(rate float64) operator.TruncationSelection {
+	return operator.TruncationSelection{Rate: rate}
+}
+
+func (f commonSelection) Boltzmann(schedule operator.Schedule) operator.BoltzmannSelection {
+	return operator.BoltzmannSelection{Schedule: schedule}
+}
+
+func (f commonSelection) Lexicase() operator.LexicaseSelection {
+	return operator.LexicaseSelection{}
+}
+
+func (f commonSelection) EpsilonLexicase(epsilon float64) operator.EpsilonLexicaseSelection {
+	return operator.EpsilonLexicaseSelection{Epsilon: epsilon}
+}
+
 func (f commonSelection) Multi() operator.MultiSelection {
 	return operator.MultiSelection{}
 }
@@ -44,6 +79,29 @@ func (f commonSurvivor) Random() operator.RandomSurvivor {
 	return operator.RandomSurvivor{}
 }
 
+func (f commonSurvivor) Comma() operator.CommaSurvivor {
+	return operator.CommaSurvivor{}
+}
+
+func (f commonSurvivor) Plus(maxAge int) operator.PlusSurvivor {
+	return operator.PlusSurvivor{MaxAge: maxAge}
+}
+
+func (f commonSurvivor) Selection(selection operator.Selection) operator.SelectionSurvivor {
+	return operator.SelectionSurvivor{Selection: selection}
+}
+
+func (f commonSurvivor) Unique(survivor operator.Survivor, mutation operator.Mutation) operator.UniqueSurvivor {
+	return operator.UniqueSurvivor{
+		Survivor: survivor,
+		Mutation: mutation,
+	}
+}
+
+func (f commonSurvivor) Quota() operator.QuotaSurvivor {
+	return operator.QuotaSurvivor{}
+}
+
 func (f commonSurvivor) Multi() operator.MultiSurvivor {
 	return operator.MultiSurvivor{}
 }
@@ -71,3 +129,27 @@ func (f commonTermination) Duration(duration time.Duration) *operator.DurationTe
 func (f commonTermination) Multi() operator.MultiTermination {
 	return operator.MultiTermination{}
 }
+
+// Scaling
+
+type commonScaling struct{}
+
+func (f commonScaling) Linear(multiple float64) operator.LinearScaling {
+	return operator.LinearScaling{Multiple: multiple}
+}
+
+func (f commonScaling) SigmaTruncation(c float64) operator.SigmaTruncationScaling {
+	return operator.SigmaTruncationScaling{C: c}
+}
+
+func (f commonScaling) Power(k float64) operator.PowerScaling {
+	return operator.PowerScaling{K: k}
+}
+
+func (f commonScaling) Windowing(window int) *operator.WindowingScaling {
+	return &operator.WindowingScaling{Window: window}
+}
+
+func (f commonScaling) Rank() operator.RankScaling {
+	return operator.RankScaling{}
+}
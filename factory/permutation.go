@@ -13,6 +13,7 @@ type Permutation struct {
 	Mutation    permutationMutation
 	Survivor    commonSurvivor
 	Termination commonTermination
+	Scaling     commonScaling
 }
 
 // Initializer
@@ -23,6 +24,26 @@ func (f permutationInitializer) Permutation() gene.PermutationInitializer {
 	return gene.PermutationInitializer{}
 }
 
+func (f permutationInitializer) Multiset(counts []int) gene.MultisetPermutationInitializer {
+	return gene.MultisetPermutationInitializer{Counts: counts}
+}
+
+func (f permutationInitializer) NearestNeighbour(cost gene.Cost) gene.NearestNeighbourInitializer {
+	return gene.NearestNeighbourInitializer{Cost: cost}
+}
+
+func (f permutationInitializer) RandomizedGreedy(cost gene.Cost, alpha float64) gene.RandomizedGreedyInitializer {
+	return gene.RandomizedGreedyInitializer{Cost: cost, Alpha: alpha}
+}
+
+func (f permutationInitializer) GreedyInsertion(cost gene.Cost) gene.GreedyInsertionInitializer {
+	return gene.GreedyInsertionInitializer{Cost: cost}
+}
+
+func (f permutationInitializer) Multi() gene.MultiInitializer {
+	return gene.MultiInitializer{}
+}
+
 // CrossOver
 
 type permutationCrossOver struct{}
@@ -39,6 +60,30 @@ func (f permutationCrossOver) PartiallyMatch() operator.PartiallyMatchCrossOver
 	return operator.PartiallyMatchCrossOver{}
 }
 
+func (f permutationCrossOver) EdgeRecombination() operator.EdgeRecombinationCrossOver {
+	return operator.EdgeRecombinationCrossOver{}
+}
+
+func (f permutationCrossOver) Cycle() operator.CycleCrossOver {
+	return operator.CycleCrossOver{}
+}
+
+func (f permutationCrossOver) OrderBased() operator.OrderBasedCrossOver {
+	return operator.OrderBasedCrossOver{}
+}
+
+func (f permutationCrossOver) PositionBased() operator.PositionBasedCrossOver {
+	return operator.PositionBasedCrossOver{}
+}
+
+func (f permutationCrossOver) EdgeAssembly(cost gene.Cost, strategy operator.EAXStrategy, merge operator.EAXMerge) operator.EdgeAssemblyCrossOver {
+	return operator.EdgeAssemblyCrossOver{Cost: cost, Strategy: strategy, Merge: merge}
+}
+
+func (f permutationCrossOver) MultiParentOrder(parents int) operator.MultiParentOrderCrossOver {
+	return operator.MultiParentOrderCrossOver{Parents: parents}
+}
+
 func (f permutationCrossOver) Multi() operator.MultiCrossOver {
 	return operator.MultiCrossOver{}
 }
@@ -59,6 +104,22 @@ func (f permutationMutation) Scramble() operator.ScramblePermutation {
 	return operator.ScramblePermutation{}
 }
 
+func (f permutationMutation) Insertion() operator.InsertionPermutation {
+	return operator.InsertionPermutation{}
+}
+
+func (f permutationMutation) Displacement() operator.DisplacementPermutation {
+	return operator.DisplacementPermutation{}
+}
+
+func (f permutationMutation) SegmentExchange() operator.SegmentExchangePermutation {
+	return operator.SegmentExchangePermutation{}
+}
+
+func (f permutationMutation) TwoOpt(cost gene.Cost) operator.TwoOptPermutation {
+	return operator.TwoOptPermutation{Cost: cost}
+}
+
 func (f permutationMutation) Multi() operator.MultiMutation {
 	return operator.MultiMutation{}
 }
@@ -13,6 +13,7 @@ type Random struct {
 	Mutation    randomMutation
 	CrossOver   randomCrossOver
 	Termination commonTermination
+	Scaling     commonScaling
 }
 
 // Initializer
@@ -25,6 +26,12 @@ func (f randomInitializer) Random(maxValue gene.B) gene.RandomInitializer {
 	}
 }
 
+func (f randomInitializer) Spec(spec gene.Spec) gene.SpecInitializer {
+	return gene.SpecInitializer{
+		Spec: spec,
+	}
+}
+
 // CrossOver
 
 type randomCrossOver struct{}
@@ -41,6 +48,34 @@ func (f randomCrossOver) Uniform() operator.UniformCrossOver {
 	return operator.UniformCrossOver{}
 }
 
+func (f randomCrossOver) KPoints(k int) operator.KPointsCrossOver {
+	return operator.KPointsCrossOver{K: k}
+}
+
+func (f randomCrossOver) BiasedUniform(bias float64) operator.UniformCrossOver {
+	return operator.UniformCrossOver{Bias: bias}
+}
+
+func (f randomCrossOver) Shuffle() operator.ShuffleCrossOver {
+	return operator.ShuffleCrossOver{}
+}
+
+func (f randomCrossOver) Segmented(switchRate float64) operator.SegmentedCrossOver {
+	return operator.SegmentedCrossOver{SwitchRate: switchRate}
+}
+
+func (f randomCrossOver) HalfUniform() operator.HalfUniformCrossOver {
+	return operator.HalfUniformCrossOver{}
+}
+
+func (f randomCrossOver) Diagonal(parents int) operator.DiagonalCrossOver {
+	return operator.DiagonalCrossOver{Parents: parents}
+}
+
+func (f randomCrossOver) Scanning(parents int, occurrence bool) operator.ScanningCrossOver {
+	return operator.ScanningCrossOver{Parents: parents, Occurrence: occurrence}
+}
+
 func (f randomCrossOver) Multi() operator.MultiCrossOver {
 	return operator.MultiCrossOver{}
 }
@@ -57,6 +92,13 @@ func (f randomMutation) Uniform() operator.UniformMutation {
 	return operator.UniformMutation{}
 }
 
+func (f randomMutation) Creep(step int, boundary operator.Boundary) operator.CreepMutation {
+	return operator.CreepMutation{
+		Step:     step,
+		Boundary: boundary,
+	}
+}
+
 func (f randomMutation) Multi() operator.MultiMutation {
 	return operator.MultiMutation{}
 }
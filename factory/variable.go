@@ -0,0 +1,69 @@
+package factory
+
+import (
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/operator"
+)
+
+// Variable factory for genes with variable-length chromosomes
+type Variable struct {
+	Initializer variableInitializer
+	Selection   commonSelection
+	Survivor    commonSurvivor
+	Mutation    variableMutation
+	CrossOver   variableCrossOver
+	Termination commonTermination
+	Scaling     commonScaling
+}
+
+// Initializer
+
+type variableInitializer struct{}
+
+func (f variableInitializer) Variable(minLen, maxLen int, maxValue gene.B) gene.VariableInitializer {
+	return gene.VariableInitializer{
+		MinLen:   minLen,
+		MaxLen:   maxLen,
+		MaxValue: maxValue,
+	}
+}
+
+// CrossOver
+
+type variableCrossOver struct{}
+
+func (f variableCrossOver) CutAndSplice(minLen, maxLen int) operator.CutAndSpliceCrossOver {
+	return operator.CutAndSpliceCrossOver{MinLen: minLen, MaxLen: maxLen}
+}
+
+func (f variableCrossOver) Messy(minLen, maxLen int) operator.MessyCrossOver {
+	return operator.MessyCrossOver{MinLen: minLen, MaxLen: maxLen}
+}
+
+func (f variableCrossOver) Multi() operator.MultiCrossOver {
+	return operator.MultiCrossOver{}
+}
+
+// Mutation
+
+type variableMutation struct{}
+
+func (f variableMutation) Insert(maxLen int) operator.InsertMutation {
+	return operator.InsertMutation{MaxLen: maxLen}
+}
+
+func (f variableMutation) Delete(minLen int) operator.DeleteMutation {
+	return operator.DeleteMutation{MinLen: minLen}
+}
+
+func (f variableMutation) Duplicate(maxLen int) operator.DuplicateMutation {
+	return operator.DuplicateMutation{MaxLen: maxLen}
+}
+
+func (f variableMutation) Unique() operator.UniqueMutation {
+	return operator.UniqueMutation{}
+}
+
+func (f variableMutation) Multi() operator.MultiMutation {
+	return operator.MultiMutation{}
+}
@@ -0,0 +1,133 @@
+package gene
+
+import (
+	"math/bits"
+
+	"github.com/sbiemont/galgogene/random"
+)
+
+// wordSize is the number of bits stored in a word
+const wordSize = 64
+
+// BitSet is a packed list of bits (64 bits per word)
+// The unused bits of the last word are always 0
+type BitSet struct {
+	words []uint64
+	size  int
+}
+
+// NewBitSet returns a full 0 initialized set of bits
+func NewBitSet(size int) BitSet {
+	return BitSet{
+		words: make([]uint64, (size+wordSize-1)/wordSize),
+		size:  size,
+	}
+}
+
+// NewBitSetRandom returns a randomly initialized set of bits
+func NewBitSetRandom(size int) BitSet {
+	bs := NewBitSet(size)
+	for i := range bs.words {
+		bs.words[i] = random.Uint64()
+	}
+	bs.ClearTail()
+	return bs
+}
+
+// Pack converts bases into bits (any base > 0 is a 1)
+func Pack(raw []B) BitSet {
+	bs := NewBitSet(len(raw))
+	for i, b := range raw {
+		if b != 0 {
+			bs.words[i/wordSize] |= 1 << (i % wordSize)
+		}
+	}
+	return bs
+}
+
+// Unpack converts bits into bases (0 or 1)
+func (bs BitSet) Unpack() []B {
+	raw := make([]B, bs.size)
+	for i := range raw {
+		if bs.Get(i) {
+			raw[i] = 1
+		}
+	}
+	return raw
+}
+
+// Len returns the number of bits
+func (bs BitSet) Len() int {
+	return bs.size
+}
+
+// Words returns the underlying words (bit #i is stored in words[i/64] at position i%64)
+// Updating the words updates the bit set, see ClearTail
+func (bs BitSet) Words() []uint64 {
+	return bs.words
+}
+
+// ClearTail resets the unused bits of the last word
+// It shall be called after a direct update of the words
+func (bs BitSet) ClearTail() {
+	if rem := bs.size % wordSize; rem != 0 {
+		bs.words[len(bs.words)-1] &= 1<<rem - 1
+	}
+}
+
+// Get returns true if the bit #i is set
+func (bs BitSet) Get(i int) bool {
+	return bs.words[i/wordSize]&(1<<(i%wordSize)) != 0
+}
+
+// Set the value of the bit #i
+func (bs BitSet) Set(i int, value bool) {
+	if value {
+		bs.words[i/wordSize] |= 1 << (i % wordSize)
+	} else {
+		bs.words[i/wordSize] &^= 1 << (i % wordSize)
+	}
+}
+
+// Flip inverts the bit #i
+func (bs BitSet) Flip(i int) {
+	bs.words[i/wordSize] ^= 1 << (i % wordSize)
+}
+
+// Clone returns a full copy of the current bit set
+func (bs BitSet) Clone() BitSet {
+	clone := NewBitSet(bs.size)
+	copy(clone.words, bs.words)
+	return clone
+}
+
+// Count returns the number of bits set
+func (bs BitSet) Count() int {
+	var count int
+	for _, word := range bs.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Distance returns the Hamming distance between both bit sets (number of different bits)
+// Both bit sets shall have the same size
+func (bs BitSet) Distance(other BitSet) int {
+	var distance int
+	for i, word := range bs.words {
+		distance += bits.OnesCount64(word ^ other.words[i])
+	}
+	return distance
+}
+
+// String exports the bit set as a string of 0 and 1
+func (bs BitSet) String() string {
+	res := make([]byte, bs.size)
+	for i := range res {
+		res[i] = '0'
+		if bs.Get(i) {
+			res[i] = '1'
+		}
+	}
+	return string(res)
+}
@@ -0,0 +1,72 @@
+package gene
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBitSet(t *testing.T) {
+	Convey("bit set", t, func() {
+		Convey("new", func() {
+			bs := NewBitSet(70)
+			So(bs.Len(), ShouldEqual, 70)
+			So(bs.Words(), ShouldHaveLength, 2)
+			So(bs.Count(), ShouldEqual, 0)
+		})
+
+		Convey("new random", func() {
+			bs := NewBitSetRandom(70)
+			So(bs.Words()[1]>>6, ShouldEqual, 0) // tail cleared
+		})
+
+		Convey("get, set, flip", func() {
+			bs := NewBitSet(70)
+			bs.Set(1, true)
+			bs.Set(65, true)
+			bs.Flip(66)
+			bs.Flip(1)
+			So(bs.Get(1), ShouldBeFalse)
+			So(bs.Get(65), ShouldBeTrue)
+			So(bs.Get(66), ShouldBeTrue)
+			So(bs.Count(), ShouldEqual, 2)
+			So(bs.Words(), ShouldResemble, []uint64{0, 0b110})
+
+			bs.Set(65, false)
+			So(bs.Get(65), ShouldBeFalse)
+		})
+
+		Convey("pack, unpack", func() {
+			raw := []B{1, 0, 0, 1, 1, 0, 2}
+			bs := Pack(raw)
+			So(bs.Words(), ShouldResemble, []uint64{0b1011001})
+			So(bs.String(), ShouldEqual, "1001101")
+			So(bs.Unpack(), ShouldResemble, []B{1, 0, 0, 1, 1, 0, 1})
+		})
+
+		Convey("clone", func() {
+			bs := Pack([]B{1, 0, 1})
+			clone := bs.Clone()
+			clone.Flip(0)
+			So(bs.String(), ShouldEqual, "101")
+			So(clone.String(), ShouldEqual, "001")
+		})
+
+		Convey("distance", func() {
+			bs1 := NewBitSet(130)
+			bs2 := NewBitSet(130)
+			bs2.Set(0, true)
+			bs2.Set(64, true)
+			bs2.Set(129, true)
+			So(bs1.Distance(bs2), ShouldEqual, 3)
+			So(bs2.Distance(bs2), ShouldEqual, 0)
+		})
+
+		Convey("clear tail", func() {
+			bs := NewBitSet(3)
+			bs.Words()[0] = ^uint64(0)
+			bs.ClearTail()
+			So(bs.Count(), ShouldEqual, 3)
+		})
+	})
+}
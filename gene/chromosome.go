@@ -15,9 +15,14 @@ func (b B) Byte() byte {
 // Chromosome represents a list of ordered bytes
 // * With maxValue = 1, the data list will be 0, 1
 // * with maxValue = 255, the data list will be 0, 1, .., 254, 255
+// A binary chromosome can also be packed: its bits are stored in Bits (Raw is not used)
 type Chromosome struct {
-	Raw      []B // The raw data list
-	maxValue B   // The max value to be applied on each byte (only useful for random operators)
+	Raw      []B          // The raw data list
+	Bits     BitSet       // The packed bits (packed chromosome only)
+	maxValue B            // The max value to be applied on each byte (only useful for random operators)
+	spec     Spec         // The allowed values of each base (optional, only useful for random operators)
+	names    []string     // The chromosome names (genome only)
+	parts    []Chromosome // The named chromosomes (genome only)
 }
 
 // NewChromosome returns a full 0 initialized set of bases
@@ -37,13 +42,114 @@ func NewChromosomeRandom(size int, maxValue B) Chromosome {
 	return result
 }
 
-// Len returns the data length
+// NewChromosomeSpec returns a full 0 initialized set of bases, with allowed values defined for each base
+func NewChromosomeSpec(size int, spec Spec) Chromosome {
+	result := NewChromosome(size, spec.maxValue())
+	result.spec = spec
+	return result
+}
+
+// NewChromosomeSpecRandom returns a randomly initialized set of bases, using the allowed values of each base
+func NewChromosomeSpecRandom(size int, spec Spec) Chromosome {
+	result := NewChromosomeSpec(size, spec)
+	for i := range size {
+		result.Raw[i] = result.RandAt(i)
+	}
+	return result
+}
+
+// NewChromosomeBits returns a full 0 initialized packed chromosome
+func NewChromosomeBits(size int) Chromosome {
+	return Chromosome{
+		Bits:     NewBitSet(size),
+		maxValue: 1,
+	}
+}
+
+// NewChromosomeBitsRandom returns a randomly initialized packed chromosome
+func NewChromosomeBitsRandom(size int) Chromosome {
+	return Chromosome{
+		Bits:     NewBitSetRandom(size),
+		maxValue: 1,
+	}
+}
+
+// IsPacked returns true if the data is stored in packed bits
+func (chrm Chromosome) IsPacked() bool {
+	return chrm.Bits.words != nil
+}
+
+// Pack returns a packed copy of the binary chromosome (any base > 0 is a 1)
+func (chrm Chromosome) Pack() Chromosome {
+	if chrm.IsPacked() {
+		return chrm.Clone()
+	}
+	return Chromosome{
+		Bits:     Pack(chrm.Raw),
+		maxValue: 1,
+	}
+}
+
+// Unpack returns a copy of the packed chromosome using one base per bit
+func (chrm Chromosome) Unpack() Chromosome {
+	if !chrm.IsPacked() {
+		return chrm.Clone()
+	}
+	return Chromosome{
+		Raw:      chrm.Bits.Unpack(),
+		maxValue: 1,
+	}
+}
+
+// IsGenome returns true if the chromosome is made of several named chromosomes
+func (chrm Chromosome) IsGenome() bool {
+	return chrm.parts != nil
+}
+
+// Genome returns the named chromosomes (a single unnamed chromosome if it is not a genome)
+// The chromosomes are not copied
+func (chrm Chromosome) Genome() Genome {
+	if !chrm.IsGenome() {
+		return Genome{Names: []string{""}, Chromosomes: []Chromosome{chrm}}
+	}
+	return Genome{Names: chrm.names, Chromosomes: chrm.parts}
+}
+
+// mapGenome returns a new genome by applying the function on each chromosome
+func (chrm Chromosome) mapGenome(fct func(i int, part Chromosome) Chromosome) Chromosome {
+	parts := make([]Chromosome, len(chrm.parts))
+	for i, part := range chrm.parts {
+		parts[i] = fct(i, part)
+	}
+	return Chromosome{names: chrm.names, parts: parts}
+}
+
+// Len returns the data length (number of bases or bits, summed over all chromosomes of a genome)
 func (chrm Chromosome) Len() int {
+	if chrm.IsGenome() {
+		var size int
+		for _, part := range chrm.parts {
+			size += part.Len()
+		}
+		return size
+	}
+	if chrm.IsPacked() {
+		return chrm.Bits.Len()
+	}
 	return len(chrm.Raw)
 }
 
 // Clone returns a full copy of the current chromosome
 func (chrm Chromosome) Clone() Chromosome {
+	if chrm.IsGenome() {
+		return chrm.mapGenome(func(_ int, part Chromosome) Chromosome { return part.Clone() })
+	}
+	if chrm.IsPacked() {
+		return Chromosome{
+			Bits:     chrm.Bits.Clone(),
+			maxValue: chrm.maxValue,
+		}
+	}
 	clone := chrm.New()
 	copy(clone.Raw, chrm.Raw)
 	return clone
@@ -51,7 +157,47 @@ func (chrm Chromosome) Clone() Chromosome {
 
 // New returns a new empty chromosome based on the current properties
 func (chrm Chromosome) New() Chromosome {
-	return NewChromosome(chrm.Len(), chrm.maxValue)
+	if chrm.IsGenome() {
+		return chrm.mapGenome(func(_ int, part Chromosome) Chromosome { return part.New() })
+	}
+	if chrm.IsPacked() {
+		return NewChromosomeBits(chrm.Len())
+	}
+	result := NewChromosome(chrm.Len(), chrm.maxValue)
+	result.spec = chrm.spec
+	return result
+}
+
+// Distance returns the Hamming distance with the other chromosome (number of different bases)
+// Both chromosomes shall have the same size and storage (or the same genome structure)
+func (chrm Chromosome) Distance(other Chromosome) int {
+	if chrm.IsGenome() {
+		var distance int
+		for i, part := range chrm.parts {
+			distance += part.Distance(other.parts[i])
+		}
+		return distance
+	}
+	if chrm.IsPacked() {
+		return chrm.Bits.Distance(other.Bits)
+	}
+	var distance int
+	for i, b := range chrm.Raw {
+		if b != other.Raw[i] {
+			distance++
+		}
+	}
+	return distance
+}
+
+// NewFrom returns a new chromosome using the given bases and the current properties
+// The bases are not copied
+func (chrm Chromosome) NewFrom(raw []B) Chromosome {
+	return Chromosome{
+		Raw:      raw,
+		maxValue: chrm.maxValue,
+		spec:     chrm.spec,
+	}
 }
 
 // Rand generates a random base using the given max value
@@ -61,8 +207,36 @@ func (chrm Chromosome) Rand() B {
 	return B(value % (uint64(chrm.maxValue) + 1))
 }
 
-// String exports the chromsome as a string
+// Spec returns the allowed values of each base (nil if not defined)
+func (chrm Chromosome) Spec() Spec {
+	return chrm.spec
+}
+
+// LocusAt returns the allowed values of the base #i (all values in [0 ; max value] if no spec is defined)
+func (chrm Chromosome) LocusAt(i int) Locus {
+	if chrm.spec == nil {
+		return Range(0, chrm.maxValue)
+	}
+	return chrm.spec.At(i)
+}
+
+// RandAt generates a random allowed value for the base #i
+func (chrm Chromosome) RandAt(i int) B {
+	if chrm.spec == nil {
+		return chrm.Rand()
+	}
+	return chrm.spec.At(i).Rand()
+}
+
+// String exports the chromsome as a string (a string of 0 and 1 for a packed chromosome)
+// The chromosomes of a genome are separated by a '|'
 func (chrm Chromosome) String() string {
+	if chrm.IsGenome() {
+		return genomeString(chrm.parts)
+	}
+	if chrm.IsPacked() {
+		return chrm.Bits.String()
+	}
 	res := make([]byte, chrm.Len())
 	for i, it := range chrm.Raw {
 		res[i] = it.Byte()
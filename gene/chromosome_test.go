@@ -37,6 +37,39 @@ func TestChromosome(t *testing.T) {
 			})
 		})
 
+		Convey("packed", func() {
+			chrm := NewChromosome(4, 1)
+			copy(chrm.Raw, []B{0, 1, 1, 0})
+			So(chrm.IsPacked(), ShouldBeFalse)
+
+			packed := chrm.Pack()
+			So(packed.IsPacked(), ShouldBeTrue)
+			So(packed.Len(), ShouldEqual, 4)
+			So(packed.String(), ShouldEqual, "0110")
+			So(packed.Unpack(), ShouldResemble, chrm)
+			So(packed.Distance(NewChromosomeBits(4)), ShouldEqual, 2)
+			So(chrm.Distance(NewChromosome(4, 1)), ShouldEqual, 2)
+
+			clone := packed.Clone()
+			clone.Bits.Flip(0)
+			So(packed.String(), ShouldEqual, "0110")
+			So(clone.String(), ShouldEqual, "1110")
+			So(packed.New().String(), ShouldEqual, "0000")
+
+			random := NewChromosomeBitsRandom(100)
+			So(random.Len(), ShouldEqual, 100)
+			So(random.Rand(), ShouldBeLessThanOrEqualTo, 1)
+		})
+
+		Convey("new from", func() {
+			chrm := NewChromosome(8, 42)
+			res := chrm.NewFrom([]B{1, 2, 3})
+			So(res, ShouldResemble, Chromosome{
+				Raw:      []B{1, 2, 3},
+				maxValue: 42,
+			})
+		})
+
 		Convey("new", func() {
 			chrm := Chromosome{
 				Raw:      []B{1, 2, 3, 4},
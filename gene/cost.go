@@ -0,0 +1,11 @@
+package gene
+
+// Cost defines the cost to go from the element #from to the element #to (like a distance between two cities)
+type Cost func(from, to int) float64
+
+// MatrixCost builds a cost function using a matrix: matrix[from][to] gives the cost
+func MatrixCost(matrix [][]float64) Cost {
+	return func(from, to int) float64 {
+		return matrix[from][to]
+	}
+}
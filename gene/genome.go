@@ -0,0 +1,126 @@
+package gene
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Genome is a set of named chromosomes of different kinds (eg.: a permutation and a list of values)
+// It is stored in the individual as one composite chromosome, see Chromosome.Genome
+type Genome struct {
+	Names       []string
+	Chromosomes []Chromosome
+}
+
+// Get returns the chromosome with the given name (and false if not found)
+func (gnm Genome) Get(name string) (Chromosome, bool) {
+	for i, n := range gnm.Names {
+		if n == name {
+			return gnm.Chromosomes[i], true
+		}
+	}
+	return Chromosome{}, false
+}
+
+// Chromosome returns the composite chromosome made of all the genome chromosomes
+func (gnm Genome) Chromosome() Chromosome {
+	return Chromosome{
+		names: gnm.Names,
+		parts: gnm.Chromosomes,
+	}
+}
+
+// GenomeFitness adapts a fitness function of a genome to a standard fitness function
+func GenomeFitness(fitness func(Genome) float64) Fitness {
+	return func(chrm Chromosome) float64 {
+		return fitness(chrm.Genome())
+	}
+}
+
+// ------------------------------
+
+// GenomePart defines one named chromosome of a genome
+type GenomePart struct {
+	Name        string
+	Size        int         // Chromosome size given to the initializer
+	Initializer Initializer // Initializer of the chromosome
+}
+
+// GenomeInitializer builds a genome using one initializer per chromosome
+// The chromosome size given to Init is not used (the engine chromosome size may be set to 0)
+type GenomeInitializer struct {
+	Parts []GenomePart
+}
+
+func (izr GenomeInitializer) Init(int) (Chromosome, error) {
+	if err := izr.check(); err != nil {
+		return Chromosome{}, err
+	}
+
+	gnm := Genome{
+		Names:       make([]string, len(izr.Parts)),
+		Chromosomes: make([]Chromosome, len(izr.Parts)),
+	}
+	for i, part := range izr.Parts {
+		chrm, err := part.Initializer.Init(part.Size)
+		if err != nil {
+			return Chromosome{}, fmt.Errorf("%s: %w", part.Name, err)
+		}
+		gnm.Names[i] = part.Name
+		gnm.Chromosomes[i] = chrm
+	}
+	return gnm.Chromosome(), nil
+}
+
+// Validate checks each chromosome of the genome using its initializer (if it implements Validator)
+func (izr GenomeInitializer) Validate(chrm Chromosome) error {
+	if err := izr.check(); err != nil {
+		return err
+	}
+	gnm := chrm.Genome()
+	for _, part := range izr.Parts {
+		partChrm, found := gnm.Get(part.Name)
+		if !found {
+			return fmt.Errorf("%s: chromosome not found", part.Name)
+		}
+		if part.Size > 0 && partChrm.Len() != part.Size {
+			return fmt.Errorf("%s: size (%d) shall be %d", part.Name, partChrm.Len(), part.Size)
+		}
+		if validator, ok := part.Initializer.(Validator); ok {
+			if err := validator.Validate(partChrm); err != nil {
+				return fmt.Errorf("%s: %w", part.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// check the parts definition
+func (izr GenomeInitializer) check() error {
+	if len(izr.Parts) == 0 {
+		return errors.New("genome shall define at least one part")
+	}
+	names := make(map[string]struct{})
+	for _, part := range izr.Parts {
+		if _, found := names[part.Name]; found {
+			return fmt.Errorf("%s: duplicated part name", part.Name)
+		}
+		names[part.Name] = struct{}{}
+		if part.Initializer == nil {
+			return fmt.Errorf("%s: initializer must be set", part.Name)
+		}
+	}
+	return nil
+}
+
+// genomeString exports all chromosomes of the genome as one string
+// Each part is prefixed by its length, so that the string identifies the genome (the raw values may contain any byte)
+func genomeString(chromosomes []Chromosome) string {
+	strs := make([]string, len(chromosomes))
+	for i, chrm := range chromosomes {
+		str := chrm.String()
+		strs[i] = fmt.Sprintf("%d:%s", len(str), str)
+	}
+	return strings.Join(strs, "|")
+}
@@ -0,0 +1,105 @@
+package gene
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenome(t *testing.T) {
+	Convey("genome", t, func() {
+		gnm := Genome{
+			Names: []string{"jobs", "machines"},
+			Chromosomes: []Chromosome{
+				{Raw: []B{2, 0, 1}, maxValue: 2},
+				{Raw: []B{1, 1, 0, 1}, maxValue: 1},
+			},
+		}
+		chrm := gnm.Chromosome()
+		So(chrm.IsGenome(), ShouldBeTrue)
+		So(chrm.Len(), ShouldEqual, 7)
+		So(chrm.String(), ShouldEqual, "3:\x02\x00\x01|4:\x01\x01\x00\x01")
+		So(chrm.Genome(), ShouldResemble, gnm)
+
+		machines, found := chrm.Genome().Get("machines")
+		So(found, ShouldBeTrue)
+		So(machines.Raw, ShouldResemble, []B{1, 1, 0, 1})
+		_, found = chrm.Genome().Get("unknown")
+		So(found, ShouldBeFalse)
+
+		Convey("when clone", func() {
+			clone := chrm.Clone()
+			clone.Genome().Chromosomes[0].Raw[0] = 0
+			So(chrm.Genome().Chromosomes[0].Raw[0], ShouldEqual, 2)
+			So(chrm.Distance(clone), ShouldEqual, 1)
+			So(chrm.New().String(), ShouldEqual, "3:\x00\x00\x00|4:\x00\x00\x00\x00")
+		})
+
+		Convey("when parts contain the separator", func() {
+			gnm1 := Genome{Names: []string{"a", "b"}, Chromosomes: []Chromosome{{Raw: []B{1, '|'}}, {Raw: []B{2}}}}
+			gnm2 := Genome{Names: []string{"a", "b"}, Chromosomes: []Chromosome{{Raw: []B{1}}, {Raw: []B{'|', 2}}}}
+			So(gnm1.Chromosome().String(), ShouldNotEqual, gnm2.Chromosome().String())
+
+			pop := Population{Individuals: []Individual{{Code: gnm1.Chromosome()}, {Code: gnm2.Chromosome()}}}
+			So(pop.Unique(), ShouldHaveLength, 2)
+		})
+
+		Convey("when simple chromosome", func() {
+			simple := Chromosome{Raw: []B{1, 2}}
+			So(simple.IsGenome(), ShouldBeFalse)
+			So(simple.Genome(), ShouldResemble, Genome{Names: []string{""}, Chromosomes: []Chromosome{simple}})
+		})
+
+		Convey("when fitness", func() {
+			fitness := GenomeFitness(func(gnm Genome) float64 {
+				return float64(len(gnm.Names))
+			})
+			So(fitness(chrm), ShouldEqual, 2)
+		})
+	})
+
+	Convey("genome initializer", t, func() {
+		izr := GenomeInitializer{
+			Parts: []GenomePart{
+				{Name: "jobs", Size: 5, Initializer: PermutationInitializer{}},
+				{Name: "machines", Size: 5, Initializer: SpecInitializer{Spec: UniformSpec(Range(0, 2))}},
+			},
+		}
+
+		Convey("when init", func() {
+			chrm, err := izr.Init(0)
+			So(err, ShouldBeNil)
+			So(chrm.Len(), ShouldEqual, 10)
+			So(izr.Validate(chrm), ShouldBeNil)
+			jobs, _ := chrm.Genome().Get("jobs")
+			So(jobs.Raw, ShouldHaveLength, 5)
+		})
+
+		Convey("when validate", func() {
+			So(izr.Validate(Genome{
+				Names:       []string{"jobs"},
+				Chromosomes: []Chromosome{{Raw: []B{0, 1, 2, 3, 4}}},
+			}.Chromosome()), ShouldBeError, "machines: chromosome not found")
+			So(izr.Validate(Genome{
+				Names:       []string{"jobs", "machines"},
+				Chromosomes: []Chromosome{{Raw: []B{0, 1, 2, 3}}, {Raw: []B{0, 1, 2, 0, 1}}},
+			}.Chromosome()), ShouldBeError, "jobs: size (4) shall be 5")
+			So(izr.Validate(Genome{
+				Names:       []string{"jobs", "machines"},
+				Chromosomes: []Chromosome{{Raw: []B{0, 1, 2, 3, 4}}, {Raw: []B{0, 1, 3, 0, 1}}},
+			}.Chromosome()), ShouldBeError, "machines: base #2 (3) is not allowed")
+		})
+
+		Convey("when invalid parts", func() {
+			_, err := GenomeInitializer{}.Init(0)
+			So(err, ShouldBeError, "genome shall define at least one part")
+			_, err = GenomeInitializer{Parts: []GenomePart{{Name: "a"}}}.Init(0)
+			So(err, ShouldBeError, "a: initializer must be set")
+			_, err = GenomeInitializer{Parts: []GenomePart{
+				{Name: "a", Initializer: PermutationInitializer{}},
+				{Name: "a", Initializer: PermutationInitializer{}},
+			}}.Init(0)
+			So(err, ShouldBeError, "a: duplicated part name")
+		})
+	})
+}
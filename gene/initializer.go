@@ -2,6 +2,8 @@ package gene
 
 import (
 	"fmt"
+	"math"
+	"slices"
 
 	"github.com/sbiemont/galgogene/random"
 )
@@ -12,6 +14,12 @@ type Initializer interface {
 	Init(chrmSize int) (Chromosome, error)
 }
 
+// Validator is an optional interface of an initializer, used to check external chromosomes (like seeds)
+type Validator interface {
+	// Validate returns an error if the chromosome could not have been produced by the initializer
+	Validate(chrm Chromosome) error
+}
+
 // ------------------------------
 
 // RandomInitializer is a full random chromosome initializer
@@ -34,6 +42,85 @@ func (izr RandomInitializer) Init(chrmSize int) (Chromosome, error) {
 	return NewChromosomeRandom(chrmSize, izr.MaxValue), nil
 }
 
+// Validate checks that each base is lower or equal to the max value
+func (izr RandomInitializer) Validate(chrm Chromosome) error {
+	for i, b := range chrm.Raw {
+		if b > izr.MaxValue {
+			return fmt.Errorf("base #%d (%d) shall be <= %d", i, b, izr.MaxValue)
+		}
+	}
+	return nil
+}
+
+// ------------------------------
+
+// SpecInitializer is a random chromosome initializer using the allowed values of each base
+type SpecInitializer struct {
+	Spec Spec
+}
+
+func (izr SpecInitializer) Init(chrmSize int) (Chromosome, error) {
+	if err := izr.Spec.check(chrmSize); err != nil {
+		return Chromosome{}, err
+	}
+	return NewChromosomeSpecRandom(chrmSize, izr.Spec), nil
+}
+
+// Validate checks that each base is allowed by the spec
+func (izr SpecInitializer) Validate(chrm Chromosome) error {
+	return izr.Spec.Validate(chrm)
+}
+
+// ------------------------------
+
+// BitInitializer is a full random packed binary chromosome initializer
+type BitInitializer struct{}
+
+func (BitInitializer) Init(chrmSize int) (Chromosome, error) {
+	if chrmSize == 0 {
+		return Chromosome{}, fmt.Errorf("chrmSize cannot be 0")
+	}
+	return NewChromosomeBitsRandom(chrmSize), nil
+}
+
+// Validate checks that the chromosome is packed
+func (BitInitializer) Validate(chrm Chromosome) error {
+	if !chrm.IsPacked() {
+		return fmt.Errorf("chromosome shall be packed")
+	}
+	return nil
+}
+
+// ------------------------------
+
+// VariableInitializer builds random chromosomes of a random size in [MinLen ; MaxLen]
+// The chromosome size given to Init is not used (the engine chromosome size may be set to 0)
+type VariableInitializer struct {
+	MinLen   int
+	MaxLen   int
+	MaxValue B
+}
+
+func (izr VariableInitializer) Init(int) (Chromosome, error) {
+	if izr.MinLen <= 0 || izr.MaxLen < izr.MinLen {
+		return Chromosome{}, fmt.Errorf("length bounds shall match 0 < min <= max")
+	}
+	if izr.MaxValue == 0 {
+		return Chromosome{}, fmt.Errorf("initializer max value cannot be 0")
+	}
+
+	size := izr.MinLen + random.IntN(izr.MaxLen-izr.MinLen+1)
+	return NewChromosomeRandom(size, izr.MaxValue), nil
+}
+
+// Validate checks the chromosome size bounds and that each base is lower or equal to the max value
+func (izr VariableInitializer) Validate(chrm Chromosome) error {
+	if chrm.Len() < izr.MinLen || chrm.Len() > izr.MaxLen {
+		return fmt.Errorf("size (%d) shall be in [%d ; %d]", chrm.Len(), izr.MinLen, izr.MaxLen)
+	}
+	return RandomInitializer{MaxValue: izr.MaxValue}.Validate(chrm)
+}
+
 // ------------------------------
 
 // PermutationInitializer builds a list of shuffled permutations
@@ -50,3 +137,284 @@ func (PermutationInitializer) Init(chrmSize int) (Chromosome, error) {
 	}
 	return result, nil
 }
+
+// Validate checks that the chromosome is a permutation of [0 ; n-1]
+func (PermutationInitializer) Validate(chrm Chromosome) error {
+	seen := make([]bool, chrm.Len())
+	for i, b := range chrm.Raw {
+		if int(b) >= chrm.Len() || seen[b] {
+			return fmt.Errorf("base #%d (%d) breaks the permutation", i, b)
+		}
+		seen[b] = true
+	}
+	return nil
+}
+
+// ------------------------------
+
+// MultisetPermutationInitializer builds shuffled permutations with repetition (eg.: job-shop schedules)
+// The value i appears Counts[i] times in the chromosome (the chromosome size is the sum of the counts)
+type MultisetPermutationInitializer struct {
+	Counts []int
+}
+
+func (izr MultisetPermutationInitializer) Init(chrmSize int) (Chromosome, error) {
+	size, err := izr.size()
+	if err != nil {
+		return Chromosome{}, err
+	}
+	if chrmSize > 0 && chrmSize != size {
+		return Chromosome{}, fmt.Errorf("chrmSize (%d) shall be 0 or %d", chrmSize, size)
+	}
+
+	result := NewChromosome(0, B(len(izr.Counts)-1))
+	for value, count := range izr.Counts {
+		for range count {
+			result.Raw = append(result.Raw, B(value))
+		}
+	}
+	random.Shuffle(result.Raw)
+	return result, nil
+}
+
+// Validate checks that each value i appears Counts[i] times in the chromosome
+func (izr MultisetPermutationInitializer) Validate(chrm Chromosome) error {
+	if _, err := izr.size(); err != nil {
+		return err
+	}
+	counts := make([]int, len(izr.Counts))
+	for i, b := range chrm.Raw {
+		if int(b) >= len(counts) {
+			return fmt.Errorf("base #%d (%d) breaks the permutation", i, b)
+		}
+		counts[b]++
+	}
+	for value, count := range counts {
+		if count != izr.Counts[value] {
+			return fmt.Errorf("value %d found %d times instead of %d", value, count, izr.Counts[value])
+		}
+	}
+	return nil
+}
+
+// size checks the counts and returns the chromosome size
+func (izr MultisetPermutationInitializer) size() (int, error) {
+	if len(izr.Counts) == 0 || len(izr.Counts) > math.MaxUint8+1 {
+		return 0, fmt.Errorf("counts size shall be in [1 ; %d]", math.MaxUint8+1)
+	}
+	var size int
+	for value, count := range izr.Counts {
+		if count < 0 {
+			return 0, fmt.Errorf("count of value %d shall be >= 0", value)
+		}
+		size += count
+	}
+	if size == 0 {
+		return 0, fmt.Errorf("counts cannot be all 0")
+	}
+	return size, nil
+}
+
+// ------------------------------
+
+// NearestNeighbourInitializer builds permutations by choosing the lowest cost element at each step
+// Only the first element is randomly chosen
+type NearestNeighbourInitializer struct {
+	Cost Cost
+}
+
+func (izr NearestNeighbourInitializer) Init(chrmSize int) (Chromosome, error) {
+	return randomizedGreedy(chrmSize, izr.Cost, 0)
+}
+
+func (NearestNeighbourInitializer) Validate(chrm Chromosome) error {
+	return PermutationInitializer{}.Validate(chrm)
+}
+
+// ------------------------------
+
+// RandomizedGreedyInitializer builds permutations by choosing, at each step, a random element
+// in a restricted candidate list (GRASP-like): all elements with a cost <= min + Alpha*(max-min)
+// * Alpha = 0: nearest neighbour
+// * Alpha = 1: full random
+type RandomizedGreedyInitializer struct {
+	Cost  Cost
+	Alpha float64 // In [0 ; 1]
+}
+
+func (izr RandomizedGreedyInitializer) Init(chrmSize int) (Chromosome, error) {
+	if izr.Alpha < 0 || izr.Alpha > 1 {
+		return Chromosome{}, fmt.Errorf("alpha shall be in [0 ; 1]")
+	}
+	return randomizedGreedy(chrmSize, izr.Cost, izr.Alpha)
+}
+
+func (RandomizedGreedyInitializer) Validate(chrm Chromosome) error {
+	return PermutationInitializer{}.Validate(chrm)
+}
+
+// randomizedGreedy builds a permutation starting from a random element
+// and choosing the next ones in the restricted candidate list
+func randomizedGreedy(chrmSize int, cost Cost, alpha float64) (Chromosome, error) {
+	if chrmSize == 0 {
+		return Chromosome{}, fmt.Errorf("chrmSize cannot be 0")
+	}
+	if cost == nil {
+		return Chromosome{}, fmt.Errorf("cost cannot be nil")
+	}
+
+	result := NewChromosome(chrmSize, B(chrmSize))
+	remaining := random.Perm(chrmSize)
+	current := remaining[len(remaining)-1]
+	remaining = remaining[:len(remaining)-1]
+	result.Raw[0] = B(current)
+	costs := make([]float64, len(remaining))
+	candidates := make([]int, 0, len(remaining))
+	for i := 1; i < chrmSize; i++ {
+		// Min and max costs from the current element
+		minCost, maxCost := math.Inf(1), math.Inf(-1)
+		for j, next := range remaining {
+			costs[j] = cost(current, next)
+			minCost = min(minCost, costs[j])
+			maxCost = max(maxCost, costs[j])
+		}
+
+		// Restricted candidate list, randomly choose the next element
+		threshold := minCost + alpha*(maxCost-minCost)
+		candidates = candidates[:0]
+		for j := range remaining {
+			if costs[j] <= threshold {
+				candidates = append(candidates, j)
+			}
+		}
+		chosen := candidates[random.IntN(len(candidates))]
+		current = remaining[chosen]
+		result.Raw[i] = B(current)
+
+		// Remove the chosen element
+		last := len(remaining) - 1
+		remaining[chosen] = remaining[last]
+		remaining = remaining[:last]
+		costs = costs[:last]
+	}
+	return result, nil
+}
+
+// ------------------------------
+
+// GreedyInsertionInitializer builds closed tours by inserting the elements (in a random order)
+// at the position of the tour that minimizes the cost increase (cheapest insertion)
+type GreedyInsertionInitializer struct {
+	Cost Cost
+}
+
+func (izr GreedyInsertionInitializer) Init(chrmSize int) (Chromosome, error) {
+	if chrmSize == 0 {
+		return Chromosome{}, fmt.Errorf("chrmSize cannot be 0")
+	}
+	if izr.Cost == nil {
+		return Chromosome{}, fmt.Errorf("cost cannot be nil")
+	}
+
+	order := random.Perm(chrmSize)
+	tour := make([]int, 1, chrmSize)
+	tour[0] = order[0]
+	for _, elt := range order[1:] {
+		// Find the cheapest insertion, after position #best
+		best, bestCost := 0, math.Inf(1)
+		for k, from := range tour {
+			to := tour[(k+1)%len(tour)]
+			increase := izr.Cost(from, elt) + izr.Cost(elt, to) - izr.Cost(from, to)
+			if increase < bestCost {
+				best, bestCost = k, increase
+			}
+		}
+		tour = slices.Insert(tour, best+1, elt)
+	}
+
+	result := NewChromosome(chrmSize, B(chrmSize))
+	for i, elt := range tour {
+		result.Raw[i] = B(elt)
+	}
+	return result, nil
+}
+
+func (GreedyInsertionInitializer) Validate(chrm Chromosome) error {
+	return PermutationInitializer{}.Validate(chrm)
+}
+
+// ------------------------------
+
+type probaInitializer struct {
+	rate float64
+	izr  Initializer
+}
+
+// MultiInitializer defines an ordered list of initializers each one with a given probability in [0 ; 1]
+// It mixes individuals (eg.: heuristic and random ones) in the initial population
+// The first chosen initializer builds the chromosome. If no initializer matches, the default one is used
+type MultiInitializer []probaInitializer
+
+// Use the given proba initializer
+func (mi MultiInitializer) Use(rate float64, initializer Initializer) MultiInitializer {
+	mi = append(mi, probaInitializer{
+		rate: rate,
+		izr:  initializer,
+	})
+	return mi
+}
+
+// Otherwise defines the initializer to be used if no initializer have been picked
+func (mi MultiInitializer) Otherwise(initializer Initializer) multiInitializer {
+	return multiInitializer{
+		initializers: mi,
+		deflt:        initializer,
+	}
+}
+
+// multiInitializer ends the initializer with a default behavior
+type multiInitializer struct {
+	initializers []probaInitializer
+	deflt        Initializer
+}
+
+func (mi multiInitializer) Init(chrmSize int) (Chromosome, error) {
+	if mi.deflt == nil {
+		return Chromosome{}, fmt.Errorf("no default initializer defined")
+	}
+
+	// Find the first initializer to be used
+	for _, proba := range mi.initializers {
+		if random.Peek(proba.rate) {
+			return proba.izr.Init(chrmSize)
+		}
+	}
+
+	// Use default initializer
+	return mi.deflt.Init(chrmSize)
+}
+
+// Validate accepts a chromosome if one of the initializers accepts it
+// An initializer without validation accepts all chromosomes
+func (mi multiInitializer) Validate(chrm Chromosome) error {
+	initializers := []Initializer{mi.deflt}
+	for _, proba := range mi.initializers {
+		initializers = append(initializers, proba.izr)
+	}
+
+	var err error
+	for _, izr := range initializers {
+		validator, ok := izr.(Validator)
+		if !ok {
+			return nil
+		}
+		errVal := validator.Validate(chrm)
+		if errVal == nil {
+			return nil
+		}
+		if err == nil {
+			err = errVal
+		}
+	}
+	return err
+}
@@ -1,6 +1,7 @@
 package gene
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -23,6 +24,44 @@ func TestInitializer(t *testing.T) {
 			chrm, err := initializer.Init(8)
 			So(err, ShouldBeNil)
 			So(countUnique(chrm.Raw), ShouldBeGreaterThan, 0)
+			So(initializer.Validate(chrm), ShouldBeNil)
+			So(initializer.Validate(Chromosome{Raw: []B{0, 8, 9}}), ShouldBeError, "base #2 (9) shall be <= 8")
+		})
+
+		Convey("spec", func() {
+			initializer := SpecInitializer{Spec: UniformSpec(Alphabet("ACGT"))}
+			chrm, err := initializer.Init(50)
+			So(err, ShouldBeNil)
+			So(countUnique(chrm.Raw), ShouldEqual, 4)
+			So(initializer.Validate(chrm), ShouldBeNil)
+
+			_, err = SpecInitializer{Spec: Spec{Printable, Printable}}.Init(3)
+			So(err, ShouldBeError, "spec size (2) shall be 1 or 3")
+		})
+
+		Convey("bits", func() {
+			chrm, err := BitInitializer{}.Init(100)
+			So(err, ShouldBeNil)
+			So(chrm.Len(), ShouldEqual, 100)
+			So(BitInitializer{}.Validate(chrm), ShouldBeNil)
+			So(BitInitializer{}.Validate(NewChromosome(100, 1)), ShouldBeError, "chromosome shall be packed")
+		})
+
+		Convey("variable", func() {
+			initializer := VariableInitializer{MinLen: 2, MaxLen: 4, MaxValue: 3}
+			sizes := make(map[int]struct{})
+			for range 50 {
+				chrm, err := initializer.Init(0)
+				So(err, ShouldBeNil)
+				So(initializer.Validate(chrm), ShouldBeNil)
+				sizes[chrm.Len()] = struct{}{}
+			}
+			So(sizes, ShouldHaveLength, 3)
+
+			So(initializer.Validate(Chromosome{Raw: []B{1}}), ShouldBeError, "size (1) shall be in [2 ; 4]")
+			So(initializer.Validate(Chromosome{Raw: []B{1, 4}}), ShouldBeError, "base #1 (4) shall be <= 3")
+			_, err := VariableInitializer{MinLen: 3, MaxLen: 2, MaxValue: 1}.Init(0)
+			So(err, ShouldBeError, "length bounds shall match 0 < min <= max")
 		})
 
 		Convey("permutation", func() {
@@ -31,6 +70,13 @@ func TestInitializer(t *testing.T) {
 				chrm, err := initializer.Init(8)
 				So(err, ShouldBeNil)
 				So(countUnique(chrm.Raw), ShouldEqual, 8)
+				So(initializer.Validate(chrm), ShouldBeNil)
+			})
+
+			Convey("when invalid", func() {
+				initializer := PermutationInitializer{}
+				So(initializer.Validate(Chromosome{Raw: []B{0, 2, 0}}), ShouldBeError, "base #2 (0) breaks the permutation")
+				So(initializer.Validate(Chromosome{Raw: []B{0, 3, 1}}), ShouldBeError, "base #1 (3) breaks the permutation")
 			})
 
 			Convey("when error", func() {
@@ -39,5 +85,117 @@ func TestInitializer(t *testing.T) {
 				So(err, ShouldNotBeNil)
 			})
 		})
+
+		Convey("multiset permutation", func() {
+			initializer := MultisetPermutationInitializer{Counts: []int{3, 1, 2}}
+
+			Convey("when ok", func() {
+				chrm, err := initializer.Init(0)
+				So(err, ShouldBeNil)
+				So(chrm.Len(), ShouldEqual, 6)
+				So(initializer.Validate(chrm), ShouldBeNil)
+				_, err = initializer.Init(6)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("when invalid", func() {
+				So(initializer.Validate(Chromosome{Raw: []B{0, 1, 0, 2, 2, 0}}), ShouldBeNil)
+				So(initializer.Validate(Chromosome{Raw: []B{0, 1, 0, 2, 2, 1}}), ShouldBeError, "value 0 found 2 times instead of 3")
+				So(initializer.Validate(Chromosome{Raw: []B{0, 1, 0, 2, 3, 0}}), ShouldBeError, "base #4 (3) breaks the permutation")
+			})
+
+			Convey("when error", func() {
+				_, err := initializer.Init(5)
+				So(err, ShouldBeError, "chrmSize (5) shall be 0 or 6")
+				_, err = MultisetPermutationInitializer{}.Init(0)
+				So(err, ShouldBeError, "counts size shall be in [1 ; 256]")
+				_, err = MultisetPermutationInitializer{Counts: []int{1, -1}}.Init(0)
+				So(err, ShouldBeError, "count of value 1 shall be >= 0")
+				_, err = MultisetPermutationInitializer{Counts: []int{0, 0}}.Init(0)
+				So(err, ShouldBeError, "counts cannot be all 0")
+			})
+		})
+
+		// Cities on a line
+		xs := []float64{0, 1, 3, 7}
+		cost := func(from, to int) float64 { return math.Abs(xs[from] - xs[to]) }
+		tourCost := func(chrm Chromosome) float64 {
+			var total float64
+			for i, b := range chrm.Raw {
+				total += cost(int(b), int(chrm.Raw[(i+1)%chrm.Len()]))
+			}
+			return total
+		}
+
+		Convey("nearest neighbour", func() {
+			initializer := NearestNeighbourInitializer{Cost: cost}
+			for range 20 {
+				chrm, err := initializer.Init(4)
+				So(err, ShouldBeNil)
+				So(initializer.Validate(chrm), ShouldBeNil)
+				So(chrm.Raw, ShouldBeIn, [][]B{{0, 1, 2, 3}, {1, 0, 2, 3}, {2, 1, 0, 3}, {3, 2, 1, 0}})
+			}
+
+			_, err := NearestNeighbourInitializer{}.Init(4)
+			So(err, ShouldBeError, "cost cannot be nil")
+		})
+
+		Convey("randomized greedy", func() {
+			initializer := RandomizedGreedyInitializer{Cost: MatrixCost([][]float64{
+				{0, 1, 3, 7},
+				{1, 0, 2, 6},
+				{3, 2, 0, 4},
+				{7, 6, 4, 0},
+			}), Alpha: 1}
+			tours := make(map[string]struct{})
+			for range 200 {
+				chrm, err := initializer.Init(4)
+				So(err, ShouldBeNil)
+				So(initializer.Validate(chrm), ShouldBeNil)
+				tours[chrm.String()] = struct{}{}
+			}
+			So(len(tours), ShouldBeGreaterThan, 4) // more than the nearest neighbour tours
+
+			_, err := RandomizedGreedyInitializer{Cost: cost, Alpha: 2}.Init(4)
+			So(err, ShouldBeError, "alpha shall be in [0 ; 1]")
+		})
+
+		Convey("greedy insertion", func() {
+			initializer := GreedyInsertionInitializer{Cost: cost}
+			for range 20 {
+				chrm, err := initializer.Init(4)
+				So(err, ShouldBeNil)
+				So(initializer.Validate(chrm), ShouldBeNil)
+				So(tourCost(chrm), ShouldEqual, 14) // optimal tour on a line
+			}
+		})
+
+		Convey("multi", func() {
+			Convey("when ok", func() {
+				initializer := MultiInitializer{}.
+					Use(1, NearestNeighbourInitializer{Cost: cost}).
+					Otherwise(PermutationInitializer{})
+				chrm, err := initializer.Init(4)
+				So(err, ShouldBeNil)
+				So(chrm.Raw, ShouldBeIn, [][]B{{0, 1, 2, 3}, {1, 0, 2, 3}, {2, 1, 0, 3}, {3, 2, 1, 0}})
+				So(initializer.Validate(chrm), ShouldBeNil)
+				So(initializer.Validate(Chromosome{Raw: []B{0, 0}}), ShouldBeError, "base #1 (0) breaks the permutation")
+			})
+
+			Convey("when default", func() {
+				initializer := MultiInitializer{}.
+					Use(0, NearestNeighbourInitializer{Cost: cost}).
+					Otherwise(RandomInitializer{MaxValue: 1})
+				chrm, err := initializer.Init(4)
+				So(err, ShouldBeNil)
+				So(chrm.Len(), ShouldEqual, 4)
+				So(initializer.Validate(Chromosome{Raw: []B{0, 0}}), ShouldBeNil) // valid for the random initializer
+			})
+
+			Convey("when no default", func() {
+				_, err := MultiInitializer{}.Otherwise(nil).Init(4)
+				So(err, ShouldBeError, "no default initializer defined")
+			})
+		})
 	})
 }
@@ -1,6 +1,8 @@
 package gene
 
 import (
+	"fmt"
+	"slices"
 	"sort"
 	"time"
 
@@ -10,32 +12,79 @@ import (
 
 // Individual represents the coded chain of bases with a given fitness
 type Individual struct {
-	ID      uuid.UUID  // Unique identifier for the individual
-	Code    Chromosome // Genetic data representation
-	Fitness float64    // Current fitness of the individual
-	Rank    int        // Generation number of the individual (starts at 0)
+	ID            uuid.UUID  // Unique identifier for the individual
+	Code          Chromosome // Genetic data representation
+	Fitness       float64    // Current fitness of the individual
+	ScaledFitness float64    // Fitness used by the selections (same as the fitness without scaling)
+	Rank          int        // Generation number of the individual (starts at 0)
+	Cases         []float64  // Optional score of each test case (see CaseFitness)
 }
 
 // NewIndividual initializes a new individual instance
 func NewIndividual(code Chromosome, fitness float64) Individual {
 	return Individual{
-		ID:      uuid.New(),
-		Code:    code,
-		Fitness: fitness,
+		ID:            uuid.New(),
+		Code:          code,
+		Fitness:       fitness,
+		ScaledFitness: fitness,
 	}
 }
 
+// Genome returns the named chromosomes of the individual
+func (indiv Individual) Genome() Genome {
+	return indiv.Code.Genome()
+}
+
 // PopulationStats gathers general data for a population
 type PopulationStats struct {
-	TotalFitness  float64
-	TotalDuration time.Duration
-	GenerationNb  int
-	Elite         Individual
+	TotalFitness       float64
+	TotalScaledFitness float64 // Sum of the scaled fitnesses (same as the total fitness without scaling)
+	TotalDuration      time.Duration
+	GenerationNb       int
+	Elite              Individual
+	Evaluations        int            // Total number of fitness evaluations since the first generation
+	Stages             StageDurations // Time spent in each stage of the engine to produce the generation
+}
+
+// StageDurations gathers the time spent in each stage of the engine to produce a generation
+// Stages run concurrently: the sum of all durations may exceed the generation duration
+type StageDurations struct {
+	Selection time.Duration
+	CrossOver time.Duration
+	Mutation  time.Duration
+	Fitness   time.Duration
+	Survivor  time.Duration
 }
 
 // Fitness defines the fitness function for a given individual
 type Fitness func(Chromosome) float64
 
+// Evaluator returns the fitness as an evaluator (without case scores)
+func (fit Fitness) Evaluator() Evaluator {
+	return func(chrm Chromosome) (float64, []float64) {
+		return fit(chrm), nil
+	}
+}
+
+// CaseFitness defines the score of an individual on each test case (the higher, the better)
+// eg.: the negated error of a program on each input / output example
+type CaseFitness func(Chromosome) []float64
+
+// Evaluator returns the case scores as an evaluator, the fitness is the sum of all scores
+func (cf CaseFitness) Evaluator() Evaluator {
+	return func(chrm Chromosome) (float64, []float64) {
+		cases := cf(chrm)
+		var fitness float64
+		for _, score := range cases {
+			fitness += score
+		}
+		return fitness, cases
+	}
+}
+
+// Evaluator computes the fitness and the optional case scores of an individual
+type Evaluator func(Chromosome) (float64, []float64)
+
 // Population represents an ordered list of individual with a common fitness function
 type Population struct {
 	Individuals []Individual
@@ -50,9 +99,38 @@ func NewPopulation(size int) Population {
 }
 
 // Init the population with random chromosome of the given size
-func (pop *Population) Init(chrmSize int, initializer Initializer, fitness Fitness) error {
-	// Full init
-	for i := range pop.Individuals {
+// Optional seeds (known solutions) are validated and injected first, the remainder is filled by the initializer
+// Seeds are checked by the initializer if it implements the Validator interface
+// The seeds size is only checked for fixed-length chromosomes (chrmSize > 0)
+func (pop *Population) Init(chrmSize int, initializer Initializer, fitness Fitness, seeds ...Chromosome) error {
+	return pop.InitWith(chrmSize, initializer, fitness.Evaluator(), seeds...)
+}
+
+// InitWith inits the population like Init, using an evaluator that also gives the case scores of each individual
+func (pop *Population) InitWith(chrmSize int, initializer Initializer, evaluator Evaluator, seeds ...Chromosome) error {
+	if len(seeds) > pop.Len() {
+		return fmt.Errorf("too many seeds (%d) for the population size (%d)", len(seeds), pop.Len())
+	}
+
+	// Seeds
+	validator, hasValidator := initializer.(Validator)
+	for i, seed := range seeds {
+		if chrmSize > 0 && seed.Len() != chrmSize {
+			return fmt.Errorf("seed #%d: size (%d) shall be %d", i, seed.Len(), chrmSize)
+		}
+		if hasValidator {
+			if err := validator.Validate(seed); err != nil {
+				return fmt.Errorf("seed #%d: %w", i, err)
+			}
+		}
+		chrm := seed.Clone()
+		pop.Individuals[i].Code = chrm
+		pop.Individuals[i].Fitness, pop.Individuals[i].Cases = evaluator(chrm)
+		pop.Individuals[i].ScaledFitness = pop.Individuals[i].Fitness
+	}
+
+	// Full init of the remainder
+	for i := len(seeds); i < pop.Len(); i++ {
 		chrm, err := initializer.Init(chrmSize)
 		if err != nil {
 			return err
@@ -60,7 +138,8 @@ func (pop *Population) Init(chrmSize int, initializer Initializer, fitness Fitne
 
 		// Update current individual
 		pop.Individuals[i].Code = chrm
-		pop.Individuals[i].Fitness = fitness(chrm)
+		pop.Individuals[i].Fitness, pop.Individuals[i].Cases = evaluator(chrm)
+		pop.Individuals[i].ScaledFitness = pop.Individuals[i].Fitness
 	}
 
 	pop.ComputeTotalFitness()
@@ -70,18 +149,42 @@ func (pop *Population) Init(chrmSize int, initializer Initializer, fitness Fitne
 // ComputeTotalFitness restart computation of total fitness
 // Compute
 //   - Total fitness
+//   - Total scaled fitness
 //   - Elite
 func (pop *Population) ComputeTotalFitness() {
 	pop.Stats.TotalFitness = 0
+	pop.Stats.TotalScaledFitness = 0
 	pop.Stats.Elite = pop.Individuals[0]
 	for _, individual := range pop.Individuals {
 		pop.Stats.TotalFitness += individual.Fitness
+		pop.Stats.TotalScaledFitness += individual.ScaledFitness
 		if individual.Fitness > pop.Stats.Elite.Fitness {
 			pop.Stats.Elite = individual
 		}
 	}
 }
 
+// Scale sets the scaled fitness of each individual (in the population order)
+// The total scaled fitness is updated by ComputeTotalFitness
+func (pop *Population) Scale(scaled []float64) {
+	for i := range pop.Individuals {
+		pop.Individuals[i].ScaledFitness = scaled[i]
+	}
+}
+
+// Scaled returns a copy of the population where the fitness of each individual is replaced by its scaled fitness
+// (the population seen by the selections)
+func (pop Population) Scaled() Population {
+	scaled := pop
+	scaled.Individuals = slices.Clone(pop.Individuals)
+	for i := range scaled.Individuals {
+		scaled.Individuals[i].Fitness = scaled.Individuals[i].ScaledFitness
+	}
+	scaled.Stats.TotalFitness = pop.Stats.TotalScaledFitness
+	scaled.Stats.Elite.Fitness = pop.Stats.Elite.ScaledFitness
+	return scaled
+}
+
 // ComputeRank move all individual to the upper rank
 func (pop *Population) ComputeRank() {
 	for i := range pop.Individuals {
@@ -148,3 +251,11 @@ func (pop Population) Unique() []Individual {
 	}
 	return result
 }
+
+// Diversity returns the ratio of unique individuals in the population (in ]0 ; 1])
+func (pop Population) Diversity() float64 {
+	if pop.Len() == 0 {
+		return 0
+	}
+	return float64(len(pop.Unique())) / float64(pop.Len())
+}
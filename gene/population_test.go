@@ -52,6 +52,76 @@ func TestPopulation(t *testing.T) {
 			})
 		})
 
+		Convey("when init with seeds", func() {
+			fitness := func(chrm Chromosome) float64 { return float64(chrm.Raw[0]) }
+			seed := NewChromosome(3, 3)
+			copy(seed.Raw, []B{2, 0, 1})
+
+			pop := NewPopulation(4)
+			So(pop.Init(3, PermutationInitializer{}, fitness, seed), ShouldBeNil)
+			So(pop.Individuals[0].Code, ShouldResemble, seed)
+			So(pop.Individuals[0].Fitness, ShouldEqual, 2)
+			So(pop.Stats.Elite.Fitness, ShouldEqual, 2)
+			for _, ind := range pop.Individuals[1:] {
+				So(ind.Code.Len(), ShouldEqual, 3)
+			}
+
+			// Seeds are copied
+			seed.Raw[0] = 0
+			So(pop.Individuals[0].Code.Raw, ShouldResemble, []B{2, 0, 1})
+
+			// Errors
+			So(pop.Init(3, PermutationInitializer{}, fitness, seed, seed, seed, seed, seed), ShouldBeError, "too many seeds (5) for the population size (4)")
+			So(pop.Init(4, PermutationInitializer{}, fitness, seed), ShouldBeError, "seed #0: size (3) shall be 4")
+			So(pop.Init(3, PermutationInitializer{}, fitness, seed), ShouldBeError, "seed #0: base #1 (0) breaks the permutation")
+		})
+
+		Convey("when init with case scores", func() {
+			cases := CaseFitness(func(chrm Chromosome) []float64 {
+				return []float64{float64(chrm.Raw[0]), -1}
+			})
+			seed := NewChromosome(3, 3)
+			copy(seed.Raw, []B{2, 0, 1})
+
+			pop := NewPopulation(2)
+			So(pop.InitWith(3, PermutationInitializer{}, cases.Evaluator(), seed), ShouldBeNil)
+			So(pop.Individuals[0].Cases, ShouldResemble, []float64{2, -1})
+			So(pop.Individuals[0].Fitness, ShouldEqual, 1)
+			So(pop.Individuals[1].Cases, ShouldHaveLength, 2)
+
+			// Without case scores
+			fitness, scores := Fitness(func(Chromosome) float64 { return 3 }).Evaluator()(seed)
+			So(fitness, ShouldEqual, 3)
+			So(scores, ShouldBeNil)
+		})
+
+		Convey("when scaled", func() {
+			pop := Population{Individuals: []Individual{NewIndividual(Chromosome{}, 1), NewIndividual(Chromosome{}, 3)}}
+			pop.ComputeTotalFitness()
+			So(pop.Stats.TotalScaledFitness, ShouldEqual, 4)
+
+			pop.Scale([]float64{0, 0.5})
+			pop.ComputeTotalFitness()
+			So(pop.Stats.TotalFitness, ShouldEqual, 4)
+			So(pop.Stats.TotalScaledFitness, ShouldEqual, 0.5)
+
+			scaled := pop.Scaled()
+			So(scaled.Individuals[0].Fitness, ShouldEqual, 0)
+			So(scaled.Individuals[1].Fitness, ShouldEqual, 0.5)
+			So(scaled.Stats.TotalFitness, ShouldEqual, 0.5)
+			So(scaled.Stats.Elite.Fitness, ShouldEqual, 0.5)
+			So(pop.Individuals[1].Fitness, ShouldEqual, 3) // unchanged
+		})
+
+		Convey("when diversity", func() {
+			So(Population{}.Diversity(), ShouldEqual, 0)
+
+			pop := Population{
+				Individuals: []Individual{ind1, ind2, ind1, ind1},
+			}
+			So(pop.Diversity(), ShouldEqual, 0.5)
+		})
+
 		Convey("when sort by rank", func() {
 			pop := Population{
 				Individuals: []Individual{
@@ -0,0 +1,223 @@
+package gene
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// maxParamBits is the max number of bits of a parameter (exact integers in a float64)
+const maxParamBits = 52
+
+// Coding defines how an unsigned integer is stored in a list of bits (most significant bit first)
+type Coding int
+
+const (
+	BinaryCoding Coding = iota // Plain binary
+	GrayCoding                 // Reflected binary Gray code: 2 consecutive values only differ by 1 bit
+)
+
+// Param defines a numeric parameter encoded in a range of bits
+// * integer: all integers in [Min ; Max], the number of bits is computed
+// (the codes are evenly shared between the integers: each one gets 1 or 2 consecutive codes)
+// * real: 2^Bits values evenly spread in [Min ; Max], Bits can be computed using the expected Precision
+type Param struct {
+	Name      string
+	Min       float64
+	Max       float64
+	Integer   bool    // Integer values (Min and Max shall be integers)
+	Bits      int     // Real values: number of bits (computed using Precision if not set)
+	Precision float64 // Real values: max step between 2 consecutive values (only used if Bits is not set)
+}
+
+// IntParam defines an integer parameter in [min ; max]
+func IntParam(name string, min, max int) Param {
+	return Param{Name: name, Min: float64(min), Max: float64(max), Integer: true}
+}
+
+// RealParam defines a real parameter in [min ; max] with a max step between 2 consecutive values
+func RealParam(name string, min, max, precision float64) Param {
+	return Param{Name: name, Min: min, Max: max, Precision: precision}
+}
+
+// maxInt returns the max integer value that can be encoded
+func (prm Param) maxInt() uint64 {
+	return 1<<prm.Bits - 1
+}
+
+// count returns the number of integers in [Min ; Max]
+func (prm Param) count() uint64 {
+	return uint64(prm.Max-prm.Min) + 1
+}
+
+// decode the integer into the parameter value
+func (prm Param) decode(value uint64) float64 {
+	if prm.Integer {
+		// value * count / 2^bits (128 bits product)
+		hi, lo := bits.Mul64(value, prm.count())
+		quo, _ := bits.Div64(hi, lo, prm.maxInt()+1)
+		return prm.Min + float64(quo)
+	}
+	return prm.Min + (prm.Max-prm.Min)*float64(value)/float64(prm.maxInt())
+}
+
+// encode the parameter value into the nearest integer
+func (prm Param) encode(value float64) (uint64, error) {
+	if value < prm.Min || value > prm.Max {
+		return 0, fmt.Errorf("%s: value %g shall be in [%g ; %g]", prm.Name, value, prm.Min, prm.Max)
+	}
+	if prm.Integer {
+		// First code of the integer: ceil(n * 2^bits / count) (128 bits product)
+		hi, lo := bits.Mul64(uint64(math.Round(value-prm.Min)), prm.maxInt()+1)
+		quo, rem := bits.Div64(hi, lo, prm.count())
+		if rem > 0 {
+			quo++
+		}
+		return quo, nil
+	}
+	return uint64(math.Round((value - prm.Min) / (prm.Max - prm.Min) * float64(prm.maxInt()))), nil
+}
+
+// check the parameter definition and compute the number of bits
+func (prm *Param) check() error {
+	switch {
+	case prm.Name == "":
+		return errors.New("param name shall be set")
+	case !(prm.Min < prm.Max):
+		return fmt.Errorf("%s: min shall be < max", prm.Name)
+	}
+
+	switch {
+	case prm.Integer:
+		if prm.Min != math.Trunc(prm.Min) || prm.Max != math.Trunc(prm.Max) {
+			return fmt.Errorf("%s: integer bounds expected", prm.Name)
+		}
+		prm.Bits = max(1, bits.Len64(uint64(prm.Max-prm.Min)))
+	case prm.Bits == 0 && prm.Precision > 0:
+		prm.Bits = max(1, int(math.Ceil(math.Log2((prm.Max-prm.Min)/prm.Precision+1))))
+	case prm.Bits <= 0:
+		return fmt.Errorf("%s: bits or precision shall be > 0", prm.Name)
+	}
+
+	if prm.Bits > maxParamBits {
+		return fmt.Errorf("%s: %d bits needed, max is %d", prm.Name, prm.Bits, maxParamBits)
+	}
+	return nil
+}
+
+// ------------------------------
+
+// Schema maps consecutive ranges of bits of a binary chromosome to numeric parameters
+// The chromosome can be either packed or not (1 bit per base, any base > 0 is a 1)
+type Schema struct {
+	coding  Coding
+	params  []Param
+	offsets []int // offsets[i] gives the first bit of the parameter #i
+	size    int
+}
+
+// NewSchema checks the parameters and computes the number of bits of each one
+func NewSchema(coding Coding, params ...Param) (Schema, error) {
+	sch := Schema{
+		coding:  coding,
+		params:  make([]Param, len(params)),
+		offsets: make([]int, len(params)),
+	}
+	names := make(map[string]struct{})
+	for i, prm := range params {
+		if err := prm.check(); err != nil {
+			return Schema{}, err
+		}
+		if _, found := names[prm.Name]; found {
+			return Schema{}, fmt.Errorf("%s: duplicated param name", prm.Name)
+		}
+		names[prm.Name] = struct{}{}
+		sch.params[i] = prm
+		sch.offsets[i] = sch.size
+		sch.size += prm.Bits
+	}
+	return sch, nil
+}
+
+// Size returns the number of bits of the chromosome
+func (sch Schema) Size() int {
+	return sch.size
+}
+
+// Params returns the parameters (with their computed number of bits)
+func (sch Schema) Params() []Param {
+	return sch.params
+}
+
+// Decode all parameters values (in the schema order)
+func (sch Schema) Decode(chrm Chromosome) []float64 {
+	values := make([]float64, len(sch.params))
+	for i, prm := range sch.params {
+		var value uint64
+		for j := sch.offsets[i]; j < sch.offsets[i]+prm.Bits; j++ {
+			value <<= 1
+			if bitAt(chrm, j) {
+				value |= 1
+			}
+		}
+		if sch.coding == GrayCoding {
+			value = fromGray(value)
+		}
+		values[i] = prm.decode(value)
+	}
+	return values
+}
+
+// DecodeNamed decodes all parameters values by name
+func (sch Schema) DecodeNamed(chrm Chromosome) map[string]float64 {
+	named := make(map[string]float64, len(sch.params))
+	for i, value := range sch.Decode(chrm) {
+		named[sch.params[i].Name] = value
+	}
+	return named
+}
+
+// Encode the parameters values (in the schema order) into a binary chromosome (1 bit per base)
+// The real values are rounded to the nearest encoded value, use Pack to get a packed chromosome
+func (sch Schema) Encode(values []float64) (Chromosome, error) {
+	if len(values) != len(sch.params) {
+		return Chromosome{}, fmt.Errorf("%d values expected, got %d", len(sch.params), len(values))
+	}
+	chrm := NewChromosome(sch.size, 1)
+	for i, prm := range sch.params {
+		value, err := prm.encode(values[i])
+		if err != nil {
+			return Chromosome{}, err
+		}
+		if sch.coding == GrayCoding {
+			value = toGray(value)
+		}
+		for j := range prm.Bits {
+			chrm.Raw[sch.offsets[i]+prm.Bits-1-j] = B(value >> j & 1)
+		}
+	}
+	return chrm, nil
+}
+
+// bitAt returns true if the bit #i of the chromosome is set
+func bitAt(chrm Chromosome, i int) bool {
+	if chrm.IsPacked() {
+		return chrm.Bits.Get(i)
+	}
+	return chrm.Raw[i] != 0
+}
+
+// toGray converts a binary value into its Gray code
+func toGray(value uint64) uint64 {
+	return value ^ value>>1
+}
+
+// fromGray converts a Gray code into its binary value
+func fromGray(gray uint64) uint64 {
+	value := gray
+	for shift := 1; shift < 64; shift <<= 1 {
+		value ^= value >> shift
+	}
+	return value
+}
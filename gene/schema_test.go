@@ -0,0 +1,116 @@
+package gene
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSchema(t *testing.T) {
+	Convey("gray", t, func() {
+		grays := []uint64{0b000, 0b001, 0b011, 0b010, 0b110, 0b111, 0b101, 0b100}
+		for value, gray := range grays {
+			So(toGray(uint64(value)), ShouldEqual, gray)
+			So(fromGray(gray), ShouldEqual, value)
+		}
+		So(fromGray(toGray(1<<52-3)), ShouldEqual, 1<<52-3)
+	})
+
+	Convey("param", t, func() {
+		Convey("when integer", func() {
+			prm := IntParam("n", -2, 3)
+			So(prm.check(), ShouldBeNil)
+			So(prm.Bits, ShouldEqual, 3)
+			So(prm.decode(0), ShouldEqual, -2)
+			So(prm.decode(7), ShouldEqual, 3)
+
+			// Each integer gets 1 or 2 codes, evenly spread
+			counts := make(map[float64]int)
+			for value := range uint64(8) {
+				counts[prm.decode(value)]++
+			}
+			So(counts, ShouldResemble, map[float64]int{-2: 2, -1: 1, 0: 1, 1: 2, 2: 1, 3: 1})
+
+			// Encode gives the first code of each integer
+			for n := -2.0; n <= 3; n++ {
+				value, err := prm.encode(n)
+				So(err, ShouldBeNil)
+				So(prm.decode(value), ShouldEqual, n)
+				So(value == 0 || prm.decode(value-1) < n, ShouldBeTrue)
+			}
+
+			// Large parameter
+			prm = IntParam("n", 0, 1<<51)
+			So(prm.check(), ShouldBeNil)
+			So(prm.Bits, ShouldEqual, 52)
+			So(prm.decode(prm.maxInt()), ShouldEqual, 1<<51)
+			value, err := prm.encode(1 << 50)
+			So(err, ShouldBeNil)
+			So(prm.decode(value), ShouldEqual, 1<<50)
+		})
+
+		Convey("when real with precision", func() {
+			prm := RealParam("x", 0, 1, 0.01)
+			So(prm.check(), ShouldBeNil)
+			So(prm.Bits, ShouldEqual, 7) // 127 steps
+			So(prm.decode(0), ShouldEqual, 0)
+			So(prm.decode(127), ShouldEqual, 1)
+		})
+
+		Convey("when errors", func() {
+			errs := map[string]Param{
+				"param name shall be set":           {Min: 0, Max: 1, Bits: 1},
+				"x: min shall be < max":             {Name: "x", Min: 1, Max: 1, Bits: 1},
+				"x: integer bounds expected":        {Name: "x", Min: 0.5, Max: 1, Integer: true},
+				"x: bits or precision shall be > 0": {Name: "x", Min: 0, Max: 1},
+				"x: 53 bits needed, max is 52":      {Name: "x", Min: 0, Max: 1, Bits: 53},
+			}
+			for msg, prm := range errs {
+				So(prm.check(), ShouldBeError, msg)
+			}
+		})
+	})
+
+	Convey("schema", t, func() {
+		for _, coding := range []Coding{BinaryCoding, GrayCoding} {
+			sch, err := NewSchema(coding, IntParam("n", 0, 9), Param{Name: "x", Min: -1, Max: 1, Bits: 8})
+			So(err, ShouldBeNil)
+			So(sch.Size(), ShouldEqual, 12)
+			So(sch.Params()[0].Bits, ShouldEqual, 4)
+
+			chrm, err := sch.Encode([]float64{7, 1})
+			So(err, ShouldBeNil)
+			So(chrm.Len(), ShouldEqual, 12)
+			So(sch.Decode(chrm), ShouldResemble, []float64{7, 1})
+			So(sch.Decode(chrm.Pack()), ShouldResemble, []float64{7, 1})
+			So(sch.DecodeNamed(chrm), ShouldResemble, map[string]float64{"n": 7, "x": 1})
+
+			// Nearest value
+			chrm, err = sch.Encode([]float64{0, 0})
+			So(err, ShouldBeNil)
+			So(sch.Decode(chrm)[1], ShouldAlmostEqual, 0, 1.0/255)
+		}
+
+		Convey("when binary or gray coding", func() {
+			binary, _ := NewSchema(BinaryCoding, IntParam("n", 0, 7))
+			gray, _ := NewSchema(GrayCoding, IntParam("n", 0, 7))
+			chrm, _ := binary.Encode([]float64{2})
+			So(chrm.Raw, ShouldResemble, []B{0, 1, 0})
+			chrm, _ = gray.Encode([]float64{2})
+			So(chrm.Raw, ShouldResemble, []B{0, 1, 1})
+		})
+
+		Convey("when errors", func() {
+			_, err := NewSchema(BinaryCoding, IntParam("n", 0, 1), IntParam("n", 0, 1))
+			So(err, ShouldBeError, "n: duplicated param name")
+			_, err = NewSchema(BinaryCoding, IntParam("n", 1, 0))
+			So(err, ShouldBeError, "n: min shall be < max")
+
+			sch, _ := NewSchema(BinaryCoding, IntParam("n", 0, 1))
+			_, err = sch.Encode([]float64{})
+			So(err, ShouldBeError, "1 values expected, got 0")
+			_, err = sch.Encode([]float64{2})
+			So(err, ShouldBeError, "n: value 2 shall be in [0 ; 1]")
+		})
+	})
+}
@@ -0,0 +1,134 @@
+package gene
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sbiemont/galgogene/random"
+)
+
+// Locus defines the allowed values of a base
+// * a set of values (if Values is not empty)
+// * otherwise, the range of values [Min ; Max]
+type Locus struct {
+	Values []B
+	Min    B
+	Max    B
+}
+
+// Range defines a locus with all values in [min ; max]
+func Range(min, max B) Locus {
+	return Locus{Min: min, Max: max}
+}
+
+// Alphabet defines a locus with a set of values (eg.: Alphabet("ACGT"))
+func Alphabet(values string) Locus {
+	return Locus{Values: []B(values)}
+}
+
+// Printable defines a locus with all printable ascii characters
+var Printable = Range(' ', '~')
+
+// Len returns the number of allowed values
+func (loc Locus) Len() int {
+	if len(loc.Values) > 0 {
+		return len(loc.Values)
+	}
+	return int(loc.Max) - int(loc.Min) + 1
+}
+
+// At returns the allowed value #i (in [0 ; Len[)
+func (loc Locus) At(i int) B {
+	if len(loc.Values) > 0 {
+		return loc.Values[i]
+	}
+	return loc.Min + B(i)
+}
+
+// IndexOf returns the index of the value in the allowed values (-1 if not allowed)
+func (loc Locus) IndexOf(value B) int {
+	if len(loc.Values) > 0 {
+		for i, v := range loc.Values {
+			if v == value {
+				return i
+			}
+		}
+		return -1
+	}
+	if value < loc.Min || value > loc.Max {
+		return -1
+	}
+	return int(value - loc.Min)
+}
+
+// Rand returns a random allowed value
+func (loc Locus) Rand() B {
+	return loc.At(int(random.Uint64() % uint64(loc.Len())))
+}
+
+// check the locus definition
+func (loc Locus) check() error {
+	if len(loc.Values) == 0 && loc.Min > loc.Max {
+		return errors.New("locus min shall be <= max")
+	}
+	return nil
+}
+
+// ------------------------------
+
+// Spec defines the allowed values of each base of a chromosome
+// A spec with only one locus applies it to all bases
+type Spec []Locus
+
+// UniformSpec defines a spec using the same locus for all bases
+func UniformSpec(locus Locus) Spec {
+	return Spec{locus}
+}
+
+// At returns the locus of the base #i
+func (spec Spec) At(i int) Locus {
+	if len(spec) == 1 {
+		return spec[0]
+	}
+	return spec[i]
+}
+
+// maxValue returns the max allowed value of all loci
+func (spec Spec) maxValue() B {
+	var maxValue B
+	for _, loc := range spec {
+		for i := range loc.Len() {
+			maxValue = max(maxValue, loc.At(i))
+		}
+	}
+	return maxValue
+}
+
+// check the spec definition for a given chromosome size
+func (spec Spec) check(size int) error {
+	if len(spec) == 0 {
+		return errors.New("spec shall define at least one locus")
+	}
+	if len(spec) > 1 && len(spec) != size {
+		return fmt.Errorf("spec size (%d) shall be 1 or %d", len(spec), size)
+	}
+	for i, loc := range spec {
+		if err := loc.check(); err != nil {
+			return fmt.Errorf("locus #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that each base of the chromosome is allowed
+func (spec Spec) Validate(chrm Chromosome) error {
+	if err := spec.check(chrm.Len()); err != nil {
+		return err
+	}
+	for i, b := range chrm.Raw {
+		if spec.At(i).IndexOf(b) < 0 {
+			return fmt.Errorf("base #%d (%d) is not allowed", i, b)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package gene
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpec(t *testing.T) {
+	Convey("locus", t, func() {
+		Convey("when range", func() {
+			loc := Range(3, 6)
+			So(loc.Len(), ShouldEqual, 4)
+			So(loc.At(1), ShouldEqual, 4)
+			So(loc.IndexOf(6), ShouldEqual, 3)
+			So(loc.IndexOf(7), ShouldEqual, -1)
+			So(loc.IndexOf(2), ShouldEqual, -1)
+			for range 20 {
+				So(loc.Rand(), ShouldBeBetweenOrEqual, 3, 6)
+			}
+			So(Range(0, 255).Len(), ShouldEqual, 256)
+		})
+
+		Convey("when alphabet", func() {
+			loc := Alphabet("ACGT")
+			So(loc.Len(), ShouldEqual, 4)
+			So(loc.At(2), ShouldEqual, 'G')
+			So(loc.IndexOf('T'), ShouldEqual, 3)
+			So(loc.IndexOf('B'), ShouldEqual, -1)
+			for range 20 {
+				So(loc.IndexOf(loc.Rand()), ShouldBeGreaterThanOrEqualTo, 0)
+			}
+		})
+	})
+
+	Convey("spec", t, func() {
+		spec := Spec{Range(0, 1), Alphabet("xyz"), Range(10, 20)}
+		So(spec.At(1), ShouldResemble, Alphabet("xyz"))
+		So(spec.maxValue(), ShouldEqual, 'z')
+		So(UniformSpec(Printable).At(42), ShouldResemble, Printable)
+
+		Convey("when validate", func() {
+			So(spec.Validate(Chromosome{Raw: []B{1, 'y', 15}}), ShouldBeNil)
+			So(spec.Validate(Chromosome{Raw: []B{1, 'a', 15}}), ShouldBeError, "base #1 (97) is not allowed")
+			So(spec.Validate(Chromosome{Raw: []B{1, 'y'}}), ShouldBeError, "spec size (3) shall be 1 or 2")
+			So(Spec{}.Validate(Chromosome{}), ShouldBeError, "spec shall define at least one locus")
+			So(Spec{Range(2, 1)}.Validate(Chromosome{}), ShouldBeError, "locus #0: locus min shall be <= max")
+		})
+
+		Convey("when chromosome", func() {
+			chrm := NewChromosomeSpecRandom(3, spec)
+			So(spec.Validate(chrm), ShouldBeNil)
+			So(chrm.Spec(), ShouldResemble, spec)
+			So(chrm.LocusAt(2), ShouldResemble, Range(10, 20))
+			So(chrm.New().Spec(), ShouldResemble, spec)
+			So(chrm.Clone().Spec(), ShouldResemble, spec)
+			So(chrm.NewFrom([]B{0, 'x', 10}).Spec(), ShouldResemble, spec)
+			for range 20 {
+				So(chrm.RandAt(1), ShouldBeIn, []B{'x', 'y', 'z'})
+			}
+
+			// Without spec
+			So(NewChromosome(3, 7).LocusAt(1), ShouldResemble, Range(0, 7))
+		})
+	})
+}
@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+)
+
+// contentType of the prometheus text exposition format
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// stages names (label values)
+var stages = [...]string{"selection", "crossover", "mutation", "fitness", "survivor"}
+
+// Collector gathers the metrics of each generation and exposes them using the prometheus text format
+// https://prometheus.io/docs/instrumenting/exposition_formats/
+type Collector struct {
+	mu sync.Mutex
+
+	generation         int
+	eliteFitness       float64
+	bestFitness        float64
+	meanFitness        float64
+	diversity          float64
+	evaluations        int     // total number of evaluations (all runs)
+	evaluationsPerSec  float64 // evaluations per second during the last generation
+	generationDuration time.Duration
+	stageDurations     [len(stages)]time.Duration // last generation
+	stageTotals        [len(stages)]time.Duration // all generations
+
+	// previous generation data
+	lastEvaluations int
+	lastDuration    time.Duration
+}
+
+// NewCollector builds an empty collector
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// OnNewGeneration records the metrics of the new generation
+// It matches the engine OnNewGeneration signature
+func (col *Collector) OnNewGeneration(pop, withBestIndividual, _ gene.Population) {
+	diversity := pop.Diversity()
+
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	// A new run restarts the generations
+	if pop.Stats.GenerationNb <= col.generation {
+		col.lastEvaluations = 0
+		col.lastDuration = 0
+	}
+
+	col.generation = pop.Stats.GenerationNb
+	col.eliteFitness = pop.Elite().Fitness
+	col.bestFitness = withBestIndividual.Elite().Fitness
+	col.meanFitness = pop.Stats.TotalFitness / float64(pop.Len())
+	col.diversity = diversity
+
+	// Evaluations since the previous generation
+	evaluations := pop.Stats.Evaluations - col.lastEvaluations
+	col.generationDuration = pop.Stats.TotalDuration - col.lastDuration
+	col.evaluations += evaluations
+	col.evaluationsPerSec = 0
+	if col.generationDuration > 0 {
+		col.evaluationsPerSec = float64(evaluations) / col.generationDuration.Seconds()
+	}
+	col.lastEvaluations = pop.Stats.Evaluations
+	col.lastDuration = pop.Stats.TotalDuration
+
+	// Stages
+	stg := pop.Stats.Stages
+	col.stageDurations = [len(stages)]time.Duration{stg.Selection, stg.CrossOver, stg.Mutation, stg.Fitness, stg.Survivor}
+	for i, d := range col.stageDurations {
+		col.stageTotals[i] += d
+	}
+}
+
+// Notify records each generation (initial population included)
+// It implements the engine Observer interface
+func (col *Collector) Notify(evt engine.Event) {
+	if evt.Kind == engine.EventInitialPopulation || evt.Kind == engine.EventGeneration {
+		col.OnNewGeneration(evt.Population, evt.WithBestIndividual, evt.WithBestTotalFitness)
+	}
+}
+
+// ServeHTTP writes all metrics using the prometheus text format
+func (col *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	_ = col.Write(w)
+}
+
+// Write all metrics using the prometheus text format
+func (col *Collector) Write(w io.Writer) error {
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	mw := metricWriter{w: w}
+	mw.metric("galgogene_generation", "gauge", "Current generation number.", float64(col.generation))
+	mw.metric("galgogene_elite_fitness", "gauge", "Fitness of the best individual of the current generation.", col.eliteFitness)
+	mw.metric("galgogene_best_fitness", "gauge", "Fitness of the best individual ever computed.", col.bestFitness)
+	mw.metric("galgogene_mean_fitness", "gauge", "Mean fitness of the current generation.", col.meanFitness)
+	mw.metric("galgogene_diversity", "gauge", "Ratio of unique individuals in the current generation.", col.diversity)
+	mw.metric("galgogene_evaluations_total", "counter", "Total number of fitness evaluations.", float64(col.evaluations))
+	mw.metric("galgogene_evaluations_per_second", "gauge", "Fitness evaluations per second during the last generation.", col.evaluationsPerSec)
+	mw.metric("galgogene_generation_duration_seconds", "gauge", "Duration of the last generation.", col.generationDuration.Seconds())
+
+	mw.header("galgogene_stage_duration_seconds", "gauge", "Time spent in each stage during the last generation.")
+	for i, stage := range stages {
+		mw.sample("galgogene_stage_duration_seconds", stage, col.stageDurations[i].Seconds())
+	}
+	mw.header("galgogene_stage_duration_seconds_total", "counter", "Total time spent in each stage.")
+	for i, stage := range stages {
+		mw.sample("galgogene_stage_duration_seconds_total", stage, col.stageTotals[i].Seconds())
+	}
+	return mw.err
+}
+
+// metricWriter writes metrics and keeps the first error
+type metricWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (mw *metricWriter) printf(format string, args ...any) {
+	if mw.err == nil {
+		_, mw.err = fmt.Fprintf(mw.w, format, args...)
+	}
+}
+
+// header writes the help and type of a metric
+func (mw *metricWriter) header(name, typ, help string) {
+	mw.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes a value labelled with a stage
+func (mw *metricWriter) sample(name, stage string, value float64) {
+	mw.printf("%s{stage=%q} %g\n", name, stage, value)
+}
+
+// metric writes a metric with a single value
+func (mw *metricWriter) metric(name, typ, help string, value float64) {
+	mw.header(name, typ, help)
+	mw.printf("%s %g\n", name, value)
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sbiemont/galgogene/engine"
+	"github.com/sbiemont/galgogene/gene"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newPopulation(fitnesses ...float64) gene.Population {
+	pop := gene.NewPopulation(len(fitnesses))
+	for i, fitness := range fitnesses {
+		pop.Individuals[i].Code = gene.NewChromosome(1, 1)
+		pop.Individuals[i].Fitness = fitness
+	}
+	pop.ComputeTotalFitness()
+	return pop
+}
+
+func TestCollector(t *testing.T) {
+	Convey("collector", t, func() {
+		col := NewCollector()
+
+		pop0 := newPopulation(0.1, 0.3)
+		pop0.Stats.Evaluations = 2
+		col.OnNewGeneration(pop0, pop0, pop0)
+
+		pop1 := newPopulation(0.2, 0.2)
+		pop1.Stats.GenerationNb = 1
+		pop1.Stats.Evaluations = 6
+		pop1.Stats.TotalDuration = 2 * time.Second
+		pop1.Stats.Stages = gene.StageDurations{
+			Selection: time.Second,
+			CrossOver: 2 * time.Second,
+			Mutation:  3 * time.Second,
+			Fitness:   4 * time.Second,
+			Survivor:  5 * time.Second,
+		}
+		col.OnNewGeneration(pop1, pop0, pop0)
+
+		rec := httptest.NewRecorder()
+		col.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		So(rec.Header().Get("Content-Type"), ShouldEqual, contentType)
+		So(rec.Body.String(), ShouldEqual, `# HELP galgogene_generation Current generation number.
+# TYPE galgogene_generation gauge
+galgogene_generation 1
+# HELP galgogene_elite_fitness Fitness of the best individual of the current generation.
+# TYPE galgogene_elite_fitness gauge
+galgogene_elite_fitness 0.2
+# HELP galgogene_best_fitness Fitness of the best individual ever computed.
+# TYPE galgogene_best_fitness gauge
+galgogene_best_fitness 0.3
+# HELP galgogene_mean_fitness Mean fitness of the current generation.
+# TYPE galgogene_mean_fitness gauge
+galgogene_mean_fitness 0.2
+# HELP galgogene_diversity Ratio of unique individuals in the current generation.
+# TYPE galgogene_diversity gauge
+galgogene_diversity 0.5
+# HELP galgogene_evaluations_total Total number of fitness evaluations.
+# TYPE galgogene_evaluations_total counter
+galgogene_evaluations_total 6
+# HELP galgogene_evaluations_per_second Fitness evaluations per second during the last generation.
+# TYPE galgogene_evaluations_per_second gauge
+galgogene_evaluations_per_second 2
+# HELP galgogene_generation_duration_seconds Duration of the last generation.
+# TYPE galgogene_generation_duration_seconds gauge
+galgogene_generation_duration_seconds 2
+# HELP galgogene_stage_duration_seconds Time spent in each stage during the last generation.
+# TYPE galgogene_stage_duration_seconds gauge
+galgogene_stage_duration_seconds{stage="selection"} 1
+galgogene_stage_duration_seconds{stage="crossover"} 2
+galgogene_stage_duration_seconds{stage="mutation"} 3
+galgogene_stage_duration_seconds{stage="fitness"} 4
+galgogene_stage_duration_seconds{stage="survivor"} 5
+# HELP galgogene_stage_duration_seconds_total Total time spent in each stage.
+# TYPE galgogene_stage_duration_seconds_total counter
+galgogene_stage_duration_seconds_total{stage="selection"} 1
+galgogene_stage_duration_seconds_total{stage="crossover"} 2
+galgogene_stage_duration_seconds_total{stage="mutation"} 3
+galgogene_stage_duration_seconds_total{stage="fitness"} 4
+galgogene_stage_duration_seconds_total{stage="survivor"} 5
+`)
+
+		Convey("when a new run starts", func() {
+			col.OnNewGeneration(pop0, pop0, pop0)
+			So(col.generation, ShouldEqual, 0)
+			So(col.evaluations, ShouldEqual, 8) // counter still increasing
+		})
+
+		Convey("when notified", func() {
+			col.Notify(engine.Event{Kind: engine.EventTermination, Population: pop0, WithBestIndividual: pop0})
+			So(col.generation, ShouldEqual, 1) // ignored event
+
+			col.Notify(engine.Event{Kind: engine.EventInitialPopulation, Population: pop0, WithBestIndividual: pop0})
+			So(col.generation, ShouldEqual, 0)
+			So(col.evaluations, ShouldEqual, 8)
+		})
+	})
+}
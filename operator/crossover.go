@@ -1,13 +1,17 @@
 package operator
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/sbiemont/galgogene/gene"
 	"github.com/sbiemont/galgogene/random"
 )
 
 // CrossOver defines the method to be used for mutating a selection of 2 chromosomes
 type CrossOver interface {
-	// Mate 2 codes to generate 2 new codes (with the same size)
+	// Mate 2 codes to generate 2 new codes
+	// Fixed-length crossovers expect 2 codes with the same size
 	Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome)
 }
 
@@ -19,6 +23,9 @@ type CrossOver interface {
 type OnePointCrossOver struct{}
 
 func (OnePointCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return BitOnePointCrossOver{}.Mate(chrm1, chrm2)
+	}
 	return crossOver(chrm1, chrm2, random.OrderedInts(0, chrm1.Len(), 1))
 }
 
@@ -28,22 +35,107 @@ func (OnePointCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, ge
 type TwoPointsCrossOver struct{}
 
 func (TwoPointsCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return BitTwoPointsCrossOver{}.Mate(chrm1, chrm2)
+	}
 	return crossOver(chrm1, chrm2, random.OrderedInts(0, chrm1.Len(), 2))
 }
 
 type ThreePointsCrossOver struct{}
 
 func (ThreePointsCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return unpackedMate(ThreePointsCrossOver{}, chrm1, chrm2)
+	}
 	return crossOver(chrm1, chrm2, random.OrderedInts(0, chrm1.Len(), 3))
 }
 
 // ------------------------------
 
-// UniformCrossOver performs a bit by bit cross-over from both parents with an equal probability of beeing chosen
-type UniformCrossOver struct{}
+// KPointsCrossOver performs cross-over with K randomly chosen distinct points
+type KPointsCrossOver struct {
+	K int // Number of points (default: 1), limited to size-1
+}
+
+func (co KPointsCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return unpackedMate(co, chrm1, chrm2)
+	}
+	return crossOver(chrm1, chrm2, randomCuts(chrm1.Len(), max(co.K, 1)))
+}
+
+// ------------------------------
+
+// UniformCrossOver performs a bit by bit cross-over from both parents
+// Each base of the first child comes from the first parent with a probability Bias (and from the second one otherwise)
+type UniformCrossOver struct {
+	Bias float64 // In ]0 ; 1[ (default: 0.5, an equal probability of beeing chosen)
+}
+
+func (co UniformCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return unpackedMate(co, chrm1, chrm2)
+	}
+	return uniformCrossOver(chrm1, chrm2, getDefault(co.Bias, 0.5))
+}
+
+// ------------------------------
+
+// ShuffleCrossOver shuffles the positions (the same way for both parents), applies a one-point cross-over,
+// then unshuffles the children, so that the cross-over does not depend on the bases positions
+type ShuffleCrossOver struct{}
+
+func (ShuffleCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return unpackedMate(ShuffleCrossOver{}, chrm1, chrm2)
+	}
+	if chrm1.Len() < 2 {
+		return chrm1.Clone(), chrm2.Clone()
+	}
+	perm := random.Perm(chrm1.Len())
+	return swapCrossOver(chrm1, chrm2, perm[1+random.IntN(chrm1.Len()-1):])
+}
+
+// ------------------------------
+
+// SegmentedCrossOver performs a multi-point cross-over with a variable number of points:
+// after each base, the parents are switched with the probability SwitchRate
+type SegmentedCrossOver struct {
+	SwitchRate float64 // In ]0 ; 1[ (default: 0.2)
+}
 
-func (UniformCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
-	return uniformCrossOver(chrm1, chrm2, 0.5)
+func (co SegmentedCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return unpackedMate(co, chrm1, chrm2)
+	}
+	rate := getDefault(co.SwitchRate, 0.2)
+	var indexes []int
+	for i := 1; i < chrm1.Len(); i++ {
+		if random.Peek(rate) {
+			indexes = append(indexes, i)
+		}
+	}
+	return crossOver(chrm1, chrm2, indexes)
+}
+
+// ------------------------------
+
+// HalfUniformCrossOver (HUX) swaps exactly half of the bases that differ between both parents (chosen at random)
+// The children are at the same distance from both parents, it is mostly used with binary strings
+type HalfUniformCrossOver struct{}
+
+func (HalfUniformCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return unpackedMate(HalfUniformCrossOver{}, chrm1, chrm2)
+	}
+	var diffs []int
+	for i, b := range chrm1.Raw {
+		if b != chrm2.Raw[i] {
+			diffs = append(diffs, i)
+		}
+	}
+	random.Shuffle(diffs)
+	return swapCrossOver(chrm1, chrm2, diffs[:len(diffs)/2])
 }
 
 // ------------------------------
@@ -86,6 +178,128 @@ func (PartiallyMatchCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromoso
 
 // ------------------------------
 
+// EdgeRecombinationCrossOver (ERX) builds children preserving the adjacency of both parents (permutation)
+// Each child starts with the first value of a parent, then goes to the neighbour (in any parent) having the
+// fewest remaining neighbours. The values shall be unique
+type EdgeRecombinationCrossOver struct{}
+
+func (EdgeRecombinationCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.Len() == 0 {
+		return chrm1, chrm2
+	}
+	return edgeRecombination(chrm1, chrm2, chrm1.Raw[0]), edgeRecombination(chrm1, chrm2, chrm2.Raw[0])
+}
+
+// ------------------------------
+
+// CycleCrossOver (CX) splits the positions into cycles and alternatively copies each cycle from a parent (permutation)
+// Each value keeps the position it has in one of the parents
+type CycleCrossOver struct{}
+
+func (CycleCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	return cycleCrossOver(chrm1, chrm2)
+}
+
+// ------------------------------
+
+// OrderBasedCrossOver (OX2) selects random positions in a parent and imposes the order of these values
+// on the other parent (permutation)
+type OrderBasedCrossOver struct{}
+
+func (OrderBasedCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	_, positions := randomPositions(chrm1.Len())
+	return orderBasedCrossOver(chrm1, chrm2, positions), orderBasedCrossOver(chrm2, chrm1, positions)
+}
+
+// ------------------------------
+
+// PositionBasedCrossOver (PBX) selects a random number of random positions kept from a parent,
+// the other positions are filled with the missing values in the order of the other parent (permutation)
+type PositionBasedCrossOver struct{}
+
+func (PositionBasedCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	mask0, mask1 := randomPositions(chrm1.Len())
+	return uniformOrderCrossOver(chrm1, chrm2, mask0, mask1), uniformOrderCrossOver(chrm2, chrm1, mask0, mask1)
+}
+
+// ------------------------------
+
+// CutAndSpliceCrossOver performs a crossover of variable-length chromosomes
+// A cut point is randomly chosen in each parent and the tails are swapped, so the children sizes may change
+// If a child size is out of the [MinLen ; MaxLen] bounds, the parents are returned unchanged
+type CutAndSpliceCrossOver struct {
+	MinLen int // Min size of a child (default: 1)
+	MaxLen int // Max size of a child (0: no limit)
+}
+
+func (co CutAndSpliceCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return unpackedMate(co, chrm1, chrm2)
+	}
+	pos1 := random.IntN(chrm1.Len() + 1)
+	pos2 := random.IntN(chrm2.Len() + 1)
+	res1, res2 := cutAndSplice(chrm1, chrm2, pos1, pos2)
+	if !inBounds(res1, co.MinLen, co.MaxLen) || !inBounds(res2, co.MinLen, co.MaxLen) {
+		return chrm1, chrm2
+	}
+	return res1, res2
+}
+
+// ------------------------------
+
+// MessyCrossOver performs a crossover of variable-length chromosomes
+// A segment is randomly chosen in each parent (independent positions and sizes) and the segments are swapped
+// If a child size is out of the [MinLen ; MaxLen] bounds, the parents are returned unchanged
+type MessyCrossOver struct {
+	MinLen int // Min size of a child (default: 1)
+	MaxLen int // Max size of a child (0: no limit)
+}
+
+func (co MessyCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if chrm1.IsPacked() {
+		return unpackedMate(co, chrm1, chrm2)
+	}
+	pos1 := random.OrderedInts(0, chrm1.Len()+1, 2)
+	pos2 := random.OrderedInts(0, chrm2.Len()+1, 2)
+	res1, res2 := messyCrossOver(chrm1, chrm2, pos1[0], pos1[1], pos2[0], pos2[1])
+	if !inBounds(res1, co.MinLen, co.MaxLen) || !inBounds(res2, co.MinLen, co.MaxLen) {
+		return chrm1, chrm2
+	}
+	return res1, res2
+}
+
+// ------------------------------
+
+// BitOnePointCrossOver performs cross-over with 1 randomly chosen point (packed chromosomes only)
+type BitOnePointCrossOver struct{}
+
+func (BitOnePointCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	return bitMaskCrossOver(chrm1, chrm2, pointsMask(random.OrderedInts(0, chrm1.Len(), 1)))
+}
+
+// ------------------------------
+
+// BitTwoPointsCrossOver performs cross-over with 2 randomly chosen points (packed chromosomes only)
+type BitTwoPointsCrossOver struct{}
+
+func (BitTwoPointsCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	return bitMaskCrossOver(chrm1, chrm2, pointsMask(random.OrderedInts(0, chrm1.Len(), 2)))
+}
+
+// ------------------------------
+
+// BitUniformCrossOver performs a bit by bit cross-over from both parents with an equal probability of beeing chosen
+// (packed chromosomes only)
+type BitUniformCrossOver struct{}
+
+func (BitUniformCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	return bitMaskCrossOver(chrm1, chrm2, func(int) uint64 {
+		return random.Uint64()
+	})
+}
+
+// ------------------------------
+
 // probaCrossOver is a probabilistic crossover
 type probaCrossOver struct {
 	rate float64   // Crossover rate
@@ -123,6 +337,63 @@ func (mco MultiCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, g
 	return res1, res2
 }
 
+// Prepare all crossovers for the given population
+func (mco MultiCrossOver) Prepare(pop gene.Population) CrossOver {
+	crossovers := make([]probaCrossOver, len(mco.crossovers))
+	for i, m := range mco.crossovers {
+		crossovers[i] = probaCrossOver{
+			rate: m.rate,
+			co:   Prepare(m.co, pop),
+		}
+	}
+	return MultiCrossOver{
+		ApplyAll:   mco.ApplyAll,
+		crossovers: crossovers,
+	}
+}
+
+// ------------------------------
+
+// GenomeCrossOver applies a specific crossover on each named chromosome of a genome
+// The chromosomes without crossover are left unchanged, a non genome chromosome is left unchanged
+type GenomeCrossOver struct {
+	crossovers map[string]CrossOver
+}
+
+// Use the given crossover on the named chromosome
+func (gco GenomeCrossOver) Use(name string, co CrossOver) GenomeCrossOver {
+	crossovers := maps.Clone(gco.crossovers)
+	if crossovers == nil {
+		crossovers = make(map[string]CrossOver)
+	}
+	crossovers[name] = co
+	return GenomeCrossOver{crossovers: crossovers}
+}
+
+func (gco GenomeCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	if !chrm1.IsGenome() || !chrm2.IsGenome() {
+		return chrm1, chrm2
+	}
+	gnm1, gnm2 := chrm1.Genome(), chrm2.Genome()
+	res1 := gene.Genome{Names: gnm1.Names, Chromosomes: slices.Clone(gnm1.Chromosomes)}
+	res2 := gene.Genome{Names: gnm2.Names, Chromosomes: slices.Clone(gnm2.Chromosomes)}
+	for i, name := range gnm1.Names {
+		if co, found := gco.crossovers[name]; found {
+			res1.Chromosomes[i], res2.Chromosomes[i] = co.Mate(gnm1.Chromosomes[i], gnm2.Chromosomes[i])
+		}
+	}
+	return res1.Chromosome(), res2.Chromosome()
+}
+
+// Prepare each crossover with the population of its named chromosome
+func (gco GenomeCrossOver) Prepare(pop gene.Population) CrossOver {
+	crossovers := make(map[string]CrossOver, len(gco.crossovers))
+	for name, co := range gco.crossovers {
+		crossovers[name] = Prepare(co, genomePopulation(pop, name))
+	}
+	return GenomeCrossOver{crossovers: crossovers}
+}
+
 // ------------------------------
 
 // Helpers
@@ -156,6 +427,35 @@ func crossOver(chrm1, chrm2 gene.Chromosome, indexes []int) (gene.Chromosome, ge
 	return res1, res2
 }
 
+// genomePopulation returns a copy of the population where each individual only keeps the named chromosome of its genome
+func genomePopulation(pop gene.Population, name string) gene.Population {
+	individuals := slices.Clone(pop.Individuals)
+	for i, ind := range individuals {
+		individuals[i].Code, _ = ind.Code.Genome().Get(name)
+	}
+	return gene.Population{Individuals: individuals, Stats: pop.Stats}
+}
+
+// unpackedMate applies a crossover working on the raw bases to packed chromosomes (the children are packed back)
+func unpackedMate(co CrossOver, chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	res1, res2 := co.Mate(chrm1.Unpack(), chrm2.Unpack())
+	return res1.Pack(), res2.Pack()
+}
+
+// swapCrossOver swaps the bases of both parents at the given positions
+// bases 1: [0 0 0 0 0 0]
+// bases 2: [1 1 1 1 1 1]
+// positions: [4 1]
+// result 1: [0 1 0 0 1 0]
+// result 2: [1 0 1 1 0 1]
+func swapCrossOver(chrm1, chrm2 gene.Chromosome, positions []int) (gene.Chromosome, gene.Chromosome) {
+	res1, res2 := chrm1.Clone(), chrm2.Clone()
+	for _, i := range positions {
+		res1.Raw[i], res2.Raw[i] = chrm2.Raw[i], chrm1.Raw[i]
+	}
+	return res1, res2
+}
+
 // uniformCrossOver swap bases with uniform distribution
 // bases 1: [0 0 0 0 0 0 0 0]
 // bases 2: [1 1 1 1 1 1 1 1]
@@ -278,33 +578,54 @@ func uniformOrderCrossOver(chrm1, chrm2 gene.Chromosome, mask0 []int, mask1 []in
 // Then copy the src => dst: 4 5 6 => 1 6 8
 // End of chrm1:             7 8   => 7 5
 // res1: 4 2 3 1 6 8 7 5
+//
+// Duplicated values (permutation with repetition) are labelled with their occurrence number
+// (eg.: 1 2 1 => 1.0 2.0 1.1), so the PMX is applied on unique labels and the values multiplicities are kept
 func partiallyMatchCrossOver(chrm1, chrm2 gene.Chromosome, pos1, pos2 int) gene.Chromosome {
 	// Ref on src and dest using given positions
-	src := chrm2.Raw[pos1:pos2]
-	dst := chrm1.Raw[pos1:pos2]
+	occ1, occ2 := occurrences(chrm1.Raw), occurrences(chrm2.Raw)
+	src := occ2[pos1:pos2]
+	dst := occ1[pos1:pos2]
 	res1 := chrm1.New()
 
 	// First part: apply pmx
 	for i := range pos1 {
-		res1.Raw[i] = pmxConvert(chrm1.Raw[i], src, dst)
+		res1.Raw[i] = pmxConvert(occ1[i], src, dst).value
 	}
 
 	// Middle part: copy the source (chrm2) into result (res1)
-	copy(res1.Raw[pos1:pos2], src)
+	copy(res1.Raw[pos1:pos2], chrm2.Raw[pos1:pos2])
 
 	// Last part: also apply pmx
 	for i := pos2; i < chrm1.Len(); i++ {
-		res1.Raw[i] = pmxConvert(chrm1.Raw[i], src, dst)
+		res1.Raw[i] = pmxConvert(occ1[i], src, dst).value
 	}
 
 	return res1
 }
 
+// occurrence labels a base with its value and its occurrence number in the chromosome
+type occurrence struct {
+	value gene.B
+	nb    int
+}
+
+// occurrences labels each base, so that a permutation with repetition becomes a permutation of unique labels
+func occurrences(raw []gene.B) []occurrence {
+	counts := make(map[gene.B]int)
+	result := make([]occurrence, len(raw))
+	for i, value := range raw {
+		result[i] = occurrence{value: value, nb: counts[value]}
+		counts[value]++
+	}
+	return result
+}
+
 // pmxConvert checks if the given value is found in src.
 // - if not found: returns the value
 // - if found: get the value at the same position in dst
 //   - relaunch pmx-convert with the new value until no convertion is found
-func pmxConvert(value gene.B, src, dst []gene.B) gene.B {
+func pmxConvert[T comparable](value T, src, dst []T) T {
 	// Find value position in src
 	idx := indexOf(src, value)
 	if idx == -1 { // not found, no convertion
@@ -315,6 +636,127 @@ func pmxConvert(value gene.B, src, dst []gene.B) gene.B {
 	return pmxConvert(dst[idx], src, dst)
 }
 
+// edgeRecombination builds a child starting with the given value
+// chrm1: A B C D E F
+// chrm2: B D C A E F
+// edges: A: F B C E, B: A C F D, C: B D A, D: C E B, E: D F A, F: E A B
+// start with A and remove A from all edges,
+// then go to one of F, C or E (2 neighbours left each, B has 3)...
+func edgeRecombination(chrm1, chrm2 gene.Chromosome, first gene.B) gene.Chromosome {
+	// Neighbours of each value in both parents (cyclic)
+	edges := make(map[gene.B][]gene.B, chrm1.Len())
+	for _, chrm := range []gene.Chromosome{chrm1, chrm2} {
+		for i, value := range chrm.Raw {
+			for _, neighbour := range []gene.B{chrm.Raw[(i+chrm.Len()-1)%chrm.Len()], chrm.Raw[(i+1)%chrm.Len()]} {
+				if neighbour != value && !slices.Contains(edges[value], neighbour) {
+					edges[value] = append(edges[value], neighbour)
+				}
+			}
+		}
+	}
+
+	res := chrm1.New()
+	remaining := slices.Clone(chrm1.Raw)
+	current := first
+	for i := range res.Raw {
+		res.Raw[i] = current
+		remaining = slices.DeleteFunc(remaining, func(value gene.B) bool { return value == current })
+		for _, neighbour := range edges[current] {
+			edges[neighbour] = slices.DeleteFunc(edges[neighbour], func(value gene.B) bool { return value == current })
+		}
+		if len(remaining) == 0 {
+			break
+		}
+
+		// Go to the neighbour with the fewest neighbours (random on ties), or to a random remaining value
+		var candidates []gene.B
+		for _, neighbour := range edges[current] {
+			switch {
+			case len(candidates) == 0 || len(edges[neighbour]) < len(edges[candidates[0]]):
+				candidates = []gene.B{neighbour}
+			case len(edges[neighbour]) == len(edges[candidates[0]]):
+				candidates = append(candidates, neighbour)
+			}
+		}
+		if len(candidates) == 0 {
+			candidates = remaining
+		}
+		current = candidates[random.IntN(len(candidates))]
+	}
+	return res
+}
+
+// cycleCrossOver copies the even cycles from the same parent and the odd cycles from the other parent
+// chrm1: 1 2 3 4 5 6 7 8
+// chrm2: 8 5 2 1 3 6 4 7
+// cycles (positions): #0 = 0 7 6 3, #1 = 1 4 2, #2 = 5
+// res1:  1 5 2 4 3 6 7 8
+// res2:  8 2 3 1 5 6 4 7
+// Duplicated values are labelled with their occurrence number to find the cycles
+func cycleCrossOver(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	occ1, occ2 := occurrences(chrm1.Raw), occurrences(chrm2.Raw)
+	positions := make(map[occurrence]int, len(occ1))
+	for i, lbl := range occ1 {
+		positions[lbl] = i
+	}
+
+	res1, res2 := chrm1.Clone(), chrm2.Clone()
+	visited := make([]bool, chrm1.Len())
+	var cycle int
+	for start := range visited {
+		if visited[start] {
+			continue
+		}
+		for i := start; !visited[i]; i = positions[occ2[i]] {
+			visited[i] = true
+			if cycle%2 == 1 {
+				res1.Raw[i], res2.Raw[i] = chrm2.Raw[i], chrm1.Raw[i]
+			}
+		}
+		cycle++
+	}
+	return res1, res2
+}
+
+// orderBasedCrossOver reorders the values of chrm1 selected at the given positions in chrm2, using the chrm2 order
+// chrm1: 1 2 3 4 5 6 7 8
+// chrm2: 2 4 6 8 7 5 3 1
+// pos:     x x     x
+// selected values in chrm2: 4 6 5
+// res:   1 2 3 4 6 5 7 8 (4 5 6 are reordered in chrm1)
+// Duplicated values are labelled with their occurrence number
+func orderBasedCrossOver(chrm1, chrm2 gene.Chromosome, positions []int) gene.Chromosome {
+	occ1, occ2 := occurrences(chrm1.Raw), occurrences(chrm2.Raw)
+	selected := make(map[occurrence]struct{}, len(positions))
+	for _, pos := range positions {
+		selected[occ2[pos]] = struct{}{}
+	}
+
+	res := chrm1.Clone()
+	var k int
+	for i, lbl := range occ1 {
+		if _, found := selected[lbl]; found && k < len(positions) {
+			res.Raw[i] = chrm2.Raw[positions[k]]
+			k++
+		}
+	}
+	return res
+}
+
+// randomPositions randomly splits the positions in [0 ; n[ into 2 sorted lists: the unselected and selected ones
+// The number of selected positions is random in [1 ; n-1]
+func randomPositions(n int) ([]int, []int) {
+	if n < 2 {
+		return make([]int, n), nil // no position can be selected
+	}
+	perm := random.Perm(n)
+	k := 1 + random.IntN(n-1)
+	unselected, selected := perm[k:], perm[:k]
+	slices.Sort(unselected)
+	slices.Sort(selected)
+	return unselected, selected
+}
+
 // indexOf resturns the first index where the value is found into the input slice ; otherwise, returns -1
 func indexOf[T comparable](slc []T, elt T) int {
 	for i, item := range slc {
@@ -324,3 +766,66 @@ func indexOf[T comparable](slc []T, elt T) int {
 	}
 	return -1
 }
+
+// cutAndSplice swaps the tails of both chromosomes, cut at pos1 (chrm1) and pos2 (chrm2)
+// chrm1: A B C|D E
+// chrm2: 1|2 3 4 5 6
+// res1:  A B C 2 3 4 5 6
+// res2:  1 D E
+func cutAndSplice(chrm1, chrm2 gene.Chromosome, pos1, pos2 int) (gene.Chromosome, gene.Chromosome) {
+	res1 := chrm1.NewFrom(slices.Concat(chrm1.Raw[:pos1], chrm2.Raw[pos2:]))
+	res2 := chrm2.NewFrom(slices.Concat(chrm2.Raw[:pos2], chrm1.Raw[pos1:]))
+	return res1, res2
+}
+
+// messyCrossOver swaps the segment [start1 ; end1[ of chrm1 with the segment [start2 ; end2[ of chrm2
+// chrm1: A|B C|D E
+// chrm2: 1 2|3 4 5|6
+// res1:  A 3 4 5 D E
+// res2:  1 2 B C 6
+func messyCrossOver(chrm1, chrm2 gene.Chromosome, start1, end1, start2, end2 int) (gene.Chromosome, gene.Chromosome) {
+	res1 := chrm1.NewFrom(slices.Concat(chrm1.Raw[:start1], chrm2.Raw[start2:end2], chrm1.Raw[end1:]))
+	res2 := chrm2.NewFrom(slices.Concat(chrm2.Raw[:start2], chrm1.Raw[start1:end1], chrm2.Raw[end2:]))
+	return res1, res2
+}
+
+// inBounds checks if the chromosome size is in [minLen ; maxLen] (minLen is at least 1, no max if maxLen is 0)
+func inBounds(chrm gene.Chromosome, minLen, maxLen int) bool {
+	return chrm.Len() >= max(minLen, 1) && (maxLen <= 0 || chrm.Len() <= maxLen)
+}
+
+// bitMaskCrossOver mixes both packed chromosomes word by word using a mask
+// For each bit of the mask:
+// * 1: keep the parent bit
+// * 0: swap the parents bits
+func bitMaskCrossOver(chrm1, chrm2 gene.Chromosome, mask func(word int) uint64) (gene.Chromosome, gene.Chromosome) {
+	res1 := chrm1.New()
+	res2 := chrm2.New()
+	words1, words2 := chrm1.Bits.Words(), chrm2.Bits.Words()
+	out1, out2 := res1.Bits.Words(), res2.Bits.Words()
+	for i := range words1 {
+		m := mask(i)
+		out1[i] = words1[i]&m | words2[i]&^m
+		out2[i] = words2[i]&m | words1[i]&^m
+	}
+	return res1, res2
+}
+
+// pointsMask builds the mask of a k-points crossover (bits are swapped from each point to the next one)
+// indexes: [    2   4   6  ]
+// mask:    [1 1 0 0 1 1 0 0]
+func pointsMask(indexes []int) func(word int) uint64 {
+	return func(word int) uint64 {
+		mask := ^uint64(0)
+		first := word * 64
+		for _, index := range indexes {
+			switch {
+			case index <= first: // invert the full word
+				mask = ^mask
+			case index < first+64: // invert from the index
+				mask ^= ^uint64(0) << (index - first)
+			}
+		}
+		return mask
+	}
+}
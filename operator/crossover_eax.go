@@ -0,0 +1,373 @@
+package operator
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/random"
+)
+
+// EAXStrategy defines how the AB-cycles are chosen to build a child (the E-set)
+type EAXStrategy int
+
+const (
+	EAXRand   EAXStrategy = iota // EAX-Rand: each AB-cycle is chosen with a probability of 50% (at least one)
+	EAXSingle                    // EAX-1AB: one random AB-cycle only (small changes, children close to the first parent)
+)
+
+// EAXMerge defines where the 2-opt move merging a sub-tour looks for the other sub-tour
+type EAXMerge int
+
+const (
+	EAXLocal  EAXMerge = iota // Among the nearest cities of the sub-tour cities (among all cities if none is found)
+	EAXGlobal                 // Among all cities
+)
+
+// EdgeAssemblyCrossOver (EAX) builds tours mostly made of the parents edges (symmetric TSP permutation)
+//   - the edges found in only one parent are split into AB-cycles (alternating edges of both parents)
+//   - a child is a parent where the parent edges of the chosen AB-cycles are replaced by the edges of the other parent
+//   - the resulting sub-tours are merged, the smallest one first, using the 2-opt move with the lowest cost increase
+//
+// The values shall be the indexes of the cities in [0 ; n[ (see gene.Cost), the cost shall be symmetric.
+// Invalid tours are returned unchanged
+type EdgeAssemblyCrossOver struct {
+	Cost       gene.Cost
+	Strategy   EAXStrategy // AB-cycles of a child (default: EAXRand)
+	Merge      EAXMerge    // Sub-tours merging (default: EAXLocal)
+	Neighbours int         // Local merging: number of nearest cities of each city (default: 10)
+	near       nearCities  // Nearest cities computed once per generation (see Prepare)
+}
+
+func (co EdgeAssemblyCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	izr := gene.PermutationInitializer{}
+	if co.Cost == nil || chrm1.Len() < 4 || chrm1.Len() != chrm2.Len() ||
+		izr.Validate(chrm1) != nil || izr.Validate(chrm2) != nil {
+		return chrm1, chrm2
+	}
+
+	tour1, tour2 := newEdges(chrm1.Raw), newEdges(chrm2.Raw)
+	cycles := abCycles(tour1, tour2)
+	if len(cycles) == 0 { // same tours
+		return chrm1.Clone(), chrm2.Clone()
+	}
+	near := co.nearCities(chrm1.Len())
+	res1 := edgeAssembly(tour1, co.eSet(cycles), true, co.Cost, near)
+	res2 := edgeAssembly(tour2, co.eSet(cycles), false, co.Cost, near)
+	return res1.chromosome(chrm1), res2.chromosome(chrm2)
+}
+
+// Prepare computes the nearest cities once for the whole generation (local merging only)
+func (co EdgeAssemblyCrossOver) Prepare(pop gene.Population) CrossOver {
+	if co.Cost == nil || co.Merge != EAXLocal || pop.Len() == 0 {
+		return co
+	}
+	co.near = newNearCities(co.Cost, pop.Individuals[0].Code.Len(), co.Neighbours)
+	for city := range co.near.cities {
+		co.near.of(city)
+	}
+	return co
+}
+
+// nearCities returns the nearest cities to be used (nil for the global merging)
+// If the crossover is not prepared, the nearest cities are only computed when needed for this mating
+func (co EdgeAssemblyCrossOver) nearCities(n int) *nearCities {
+	switch {
+	case co.Merge != EAXLocal:
+		return nil
+	case len(co.near.cities) == n:
+		return &co.near
+	default:
+		near := newNearCities(co.Cost, n, co.Neighbours)
+		return &near
+	}
+}
+
+// eSet chooses the AB-cycles to be applied
+func (co EdgeAssemblyCrossOver) eSet(cycles [][]abEdge) [][]abEdge {
+	if co.Strategy == EAXSingle {
+		return [][]abEdge{cycles[random.IntN(len(cycles))]}
+	}
+
+	var result [][]abEdge
+	for _, cycle := range cycles {
+		if random.Peek(0.5) {
+			result = append(result, cycle)
+		}
+	}
+	if len(result) == 0 {
+		result = append(result, cycles[random.IntN(len(cycles))])
+	}
+	return result
+}
+
+// ------------------------------
+
+// edges gives the 2 neighbours of each city (-1 if not set)
+type edges [][2]int
+
+// newEdges builds the edges of a tour
+func newEdges(tour []gene.B) edges {
+	result := make(edges, len(tour))
+	for i, city := range tour {
+		result[city] = [2]int{int(tour[(i+len(tour)-1)%len(tour)]), int(tour[(i+1)%len(tour)])}
+	}
+	return result
+}
+
+// has returns true if the edge (u, v) exists
+func (edg edges) has(u, v int) bool {
+	return edg[u][0] == v || edg[u][1] == v
+}
+
+// remove the edge (u, v)
+func (edg edges) remove(u, v int) {
+	edg.replace(u, v, -1)
+	edg.replace(v, u, -1)
+}
+
+// add the edge (u, v), both cities shall have a free slot
+func (edg edges) add(u, v int) {
+	edg.replace(u, -1, v)
+	edg.replace(v, -1, u)
+}
+
+// replace the neighbour "from" of u by "to"
+func (edg edges) replace(u, from, to int) {
+	if edg[u][0] == from {
+		edg[u][0] = to
+	} else {
+		edg[u][1] = to
+	}
+}
+
+// next returns the neighbour of cur that is not prev
+func (edg edges) next(prev, cur int) int {
+	if edg[cur][0] == prev {
+		return edg[cur][1]
+	}
+	return edg[cur][0]
+}
+
+// subTours returns the sub-tours (list of cities) and the sub-tour index of each city
+func (edg edges) subTours() ([][]int, []int) {
+	var tours [][]int
+	index := make([]int, len(edg))
+	for i := range index {
+		index[i] = -1
+	}
+	for start := range edg {
+		if index[start] >= 0 {
+			continue
+		}
+		var tour []int
+		prev, cur := edg[start][1], start
+		for index[cur] < 0 {
+			index[cur] = len(tours)
+			tour = append(tour, cur)
+			prev, cur = cur, edg.next(prev, cur)
+		}
+		tours = append(tours, tour)
+	}
+	return tours, index
+}
+
+// chromosome exports the tour starting with the same city as the given chromosome
+func (edg edges) chromosome(chrm gene.Chromosome) gene.Chromosome {
+	result := chrm.New()
+	prev, cur := edg[chrm.Raw[0]][1], int(chrm.Raw[0])
+	for i := range result.Raw {
+		result.Raw[i] = gene.B(cur)
+		prev, cur = cur, edg.next(prev, cur)
+	}
+	return result
+}
+
+// ------------------------------
+
+// nearCities gives the k nearest cities of each city (computed on first use)
+type nearCities struct {
+	cost   gene.Cost
+	k      int
+	cities [][]int
+}
+
+func newNearCities(cost gene.Cost, n, k int) nearCities {
+	if k <= 0 {
+		k = 10
+	}
+	return nearCities{cost: cost, k: min(k, n-1), cities: make([][]int, n)}
+}
+
+// of returns the nearest cities of the given city, the nearest first
+func (near nearCities) of(city int) []int {
+	if near.cities[city] != nil {
+		return near.cities[city]
+	}
+	others := make([]int, 0, len(near.cities)-1)
+	for other := range near.cities {
+		if other != city {
+			others = append(others, other)
+		}
+	}
+	slices.SortFunc(others, func(c1, c2 int) int {
+		return cmp.Compare(near.cost(city, c1), near.cost(city, c2))
+	})
+	near.cities[city] = slices.Clip(others[:near.k])
+	return near.cities[city]
+}
+
+// ------------------------------
+
+// abEdge is an edge of an AB-cycle, from the first parent (A) or the second one (B)
+type abEdge struct {
+	u, v int
+	isA  bool
+}
+
+// abCycles splits the edges found in only one parent into AB-cycles (alternating A and B edges)
+// A random walk alternatively follows an unused A edge and an unused B edge, a cycle is closed
+// as soon as the walk comes back to a city of the path with an even number of edges
+func abCycles(tourA, tourB edges) [][]abEdge {
+	// Remaining edges of each kind, for each city
+	remaining := [2][][]int{make([][]int, len(tourA)), make([][]int, len(tourA))} // 0: A, 1: B
+	for u := range tourA {
+		for _, v := range tourA[u] {
+			if !tourB.has(u, v) {
+				remaining[0][u] = append(remaining[0][u], v)
+			}
+		}
+		for _, v := range tourB[u] {
+			if !tourA.has(u, v) {
+				remaining[1][u] = append(remaining[1][u], v)
+			}
+		}
+	}
+	take := func(kind, u int) int {
+		nbs := remaining[kind][u]
+		i := random.IntN(len(nbs))
+		v := nbs[i]
+		remaining[kind][u] = slices.Delete(nbs, i, i+1)
+		nbs = remaining[kind][v]
+		remaining[kind][v] = slices.Delete(nbs, indexOf(nbs, u), indexOf(nbs, u)+1)
+		return v
+	}
+
+	var cycles [][]abEdge
+	for start := range tourA {
+		path := []int{start}
+		for len(remaining[0][start]) > 0 || len(path) > 1 {
+			// The edge #k of the path is an A edge if k is even
+			kind := (len(path) - 1) % 2
+			u := path[len(path)-1]
+			if len(remaining[kind][u]) == 0 { // shall not happen with valid tours
+				return nil
+			}
+			path = append(path, take(kind, u))
+
+			// Close the cycle if possible
+			last := len(path) - 1
+			for j := last - 2; j >= 0; j -= 2 {
+				if path[j] == path[last] {
+					cycle := make([]abEdge, 0, last-j)
+					for k := j; k < last; k++ {
+						cycle = append(cycle, abEdge{u: path[k], v: path[k+1], isA: k%2 == 0})
+					}
+					cycles = append(cycles, cycle)
+					path = path[:j+1]
+					break
+				}
+			}
+		}
+	}
+	return cycles
+}
+
+// edgeAssembly applies the AB-cycles on the parent tour and merges the sub-tours
+// fromA is true if the parent is A (the A edges are removed and the B edges added), otherwise the opposite
+// The sub-tours are merged using the nearest cities (or all cities if near is nil)
+func edgeAssembly(parent edges, eSet [][]abEdge, fromA bool, cost gene.Cost, near *nearCities) edges {
+	result := make(edges, len(parent))
+	copy(result, parent)
+
+	// Intermediate solution: remove all parent edges first, then add the other parent edges
+	for _, removed := range []bool{true, false} {
+		for _, cycle := range eSet {
+			for _, edge := range cycle {
+				if (edge.isA == fromA) == removed {
+					if removed {
+						result.remove(edge.u, edge.v)
+					} else {
+						result.add(edge.u, edge.v)
+					}
+				}
+			}
+		}
+	}
+
+	// Merge sub-tours, the smallest one first (the sub-tour indexes are updated after each merge)
+	tours, index := result.subTours()
+	for remaining := len(tours); remaining > 1; remaining-- {
+		smallest := -1
+		for i, tour := range tours {
+			if tour != nil && (smallest < 0 || len(tour) < len(tours[smallest])) {
+				smallest = i
+			}
+		}
+		other := mergeSubTour(result, tours[smallest], index, cost, near)
+		for _, city := range tours[smallest] {
+			index[city] = other
+		}
+		tours[other] = append(tours[other], tours[smallest]...)
+		tours[smallest] = nil
+	}
+	return result
+}
+
+// mergeSubTour merges the sub-tour with another one using a 2-opt move and returns the index of the other sub-tour:
+// the edges (a, b) of the sub-tour and (c, d) of another sub-tour are replaced by (a, c) (b, d) or (a, d) (b, c)
+// The move with the lowest cost increase is chosen, c is one of the nearest cities of a (or any city if near is nil)
+func mergeSubTour(result edges, tour []int, index []int, cost gene.Cost, near *nearCities) int {
+	bestDelta := math.Inf(1)
+	var best [4]int // a b c d, replaced by (a, c) (b, d)
+	var all []int
+	if near == nil {
+		all = make([]int, len(result))
+		for city := range all {
+			all[city] = city
+		}
+	}
+	for _, a := range tour {
+		candidates := all
+		if near != nil {
+			candidates = near.of(a)
+		}
+		for _, b := range result[a] {
+			for _, c := range candidates {
+				if index[c] == index[a] {
+					continue
+				}
+				for _, d := range result[c] {
+					removed := cost(a, b) + cost(c, d)
+					if delta := cost(a, c) + cost(b, d) - removed; delta < bestDelta {
+						bestDelta, best = delta, [4]int{a, b, c, d}
+					}
+					if delta := cost(a, d) + cost(b, c) - removed; delta < bestDelta {
+						bestDelta, best = delta, [4]int{a, b, d, c}
+					}
+				}
+			}
+		}
+	}
+	if math.IsInf(bestDelta, 1) && near != nil { // no other sub-tour near this one
+		return mergeSubTour(result, tour, index, cost, nil)
+	}
+
+	a, b, c, d := best[0], best[1], best[2], best[3]
+	result.remove(a, b)
+	result.remove(c, d)
+	result.add(a, c)
+	result.add(b, d)
+	return index[c]
+}
@@ -0,0 +1,177 @@
+package operator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/random"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEdgeAssemblyCrossOver(t *testing.T) {
+	// Cities on a circle: the best tour follows the circle
+	const n = 40
+	cost := func(from, to int) float64 {
+		a1, a2 := 2*math.Pi*float64(from)/n, 2*math.Pi*float64(to)/n
+		return math.Hypot(math.Cos(a1)-math.Cos(a2), math.Sin(a1)-math.Sin(a2))
+	}
+	tourCost := func(chrm gene.Chromosome) float64 {
+		var total float64
+		for i, city := range chrm.Raw {
+			total += cost(int(city), int(chrm.Raw[(i+1)%chrm.Len()]))
+		}
+		return total
+	}
+	izr := gene.PermutationInitializer{}
+
+	Convey("ab-cycles", t, func() {
+		tourA := newEdges([]gene.B{0, 1, 2, 3, 4, 5})
+		tourB := newEdges([]gene.B{0, 2, 1, 3, 5, 4})
+
+		// Edges only in A: 0-1 2-3 4-5 5-0 ; only in B: 0-2 1-3 4-0 5-3
+		cycles := abCycles(tourA, tourB)
+		var nbEdges int
+		for _, cycle := range cycles {
+			So(len(cycle)%2, ShouldEqual, 0)
+			for k, edge := range cycle {
+				So(edge.isA, ShouldEqual, k%2 == 0)
+				So(edge.v, ShouldEqual, cycle[(k+1)%len(cycle)].u)
+				if edge.isA {
+					So(tourA.has(edge.u, edge.v), ShouldBeTrue)
+				} else {
+					So(tourB.has(edge.u, edge.v), ShouldBeTrue)
+				}
+			}
+			nbEdges += len(cycle)
+		}
+		So(nbEdges, ShouldEqual, 8)
+		So(abCycles(tourA, tourA), ShouldBeEmpty)
+	})
+
+	Convey("nearest cities", t, func() {
+		near := newNearCities(cost, n, 2)
+		So(near.of(0), ShouldBeIn, [][]int{{1, 39}, {39, 1}})
+		So(near.of(5), ShouldBeIn, [][]int{{4, 6}, {6, 4}})
+		So(newNearCities(cost, 5, 0).of(0), ShouldHaveLength, 4)
+	})
+
+	Convey("sub-tours", t, func() {
+		absCost := func(from, to int) float64 { return math.Abs(float64(from - to)) }
+		for _, near := range []*nearCities{nil, {cost: absCost, k: 1, cities: make([][]int, 6)}} {
+			edg := newEdges([]gene.B{0, 1, 2, 3, 4, 5})
+			edg.remove(2, 3)
+			edg.remove(5, 0)
+			edg.add(2, 0)
+			edg.add(5, 3)
+			tours, index := edg.subTours()
+			So(tours, ShouldHaveLength, 2)
+			So(index, ShouldResemble, []int{0, 0, 0, 1, 1, 1})
+
+			So(mergeSubTour(edg, tours[0], index, absCost, near), ShouldEqual, 1)
+			tours, _ = edg.subTours()
+			So(tours, ShouldHaveLength, 1)
+			So(izr.Validate(edg.chromosome(newChromosome([]gene.B{0, 1, 2, 3, 4, 5}))), ShouldBeNil)
+		}
+	})
+
+	Convey("sub-tours without near cities", t, func() {
+		// The nearest city of each city is in the same sub-tour: all cities are searched
+		edg := newEdges([]gene.B{0, 1, 2, 3})
+		edg.remove(1, 2)
+		edg.remove(3, 0)
+		edg.add(1, 0)
+		edg.add(3, 2)
+		tours, index := edg.subTours()
+		So(tours, ShouldHaveLength, 2)
+
+		pairCost := func(from, to int) float64 {
+			if from/2 == to/2 {
+				return 1
+			}
+			return 10
+		}
+		near := newNearCities(pairCost, 4, 1)
+		So(mergeSubTour(edg, tours[0], index, pairCost, &near), ShouldEqual, 1)
+		tours, _ = edg.subTours()
+		So(tours, ShouldHaveLength, 1)
+	})
+
+	Convey("mate", t, func() {
+		for _, strategy := range []EAXStrategy{EAXRand, EAXSingle} {
+			for _, merge := range []EAXMerge{EAXLocal, EAXGlobal} {
+				co := EdgeAssemblyCrossOver{Cost: cost, Strategy: strategy, Merge: merge, Neighbours: 3}
+				for range 20 {
+					chrm1, _ := izr.Init(n)
+					chrm2, _ := izr.Init(n)
+					res1, res2 := co.Mate(chrm1, chrm2)
+					So(izr.Validate(res1), ShouldBeNil)
+					So(izr.Validate(res2), ShouldBeNil)
+					So(res1.Raw[0], ShouldEqual, chrm1.Raw[0])
+					So(res2.Raw[0], ShouldEqual, chrm2.Raw[0])
+				}
+			}
+		}
+	})
+
+	Convey("prepare", t, func() {
+		chrm1, _ := izr.Init(n)
+		chrm2, _ := izr.Init(n)
+		pop := gene.Population{Individuals: []gene.Individual{{Code: chrm1}, {Code: chrm2}}}
+
+		co := EdgeAssemblyCrossOver{Cost: cost}.Prepare(pop).(EdgeAssemblyCrossOver)
+		So(co.near.cities, ShouldHaveLength, n)
+		for _, cities := range co.near.cities {
+			So(cities, ShouldHaveLength, 10)
+		}
+		res1, res2 := co.Mate(chrm1, chrm2)
+		So(izr.Validate(res1), ShouldBeNil)
+		So(izr.Validate(res2), ShouldBeNil)
+
+		global := EdgeAssemblyCrossOver{Cost: cost, Merge: EAXGlobal}.Prepare(pop).(EdgeAssemblyCrossOver)
+		So(global.near.cities, ShouldBeNil)
+	})
+
+	Convey("mate improves tours", t, func() {
+		optimal := tourCost(newChromosome(func() []gene.B {
+			raw := make([]gene.B, n)
+			for i := range raw {
+				raw[i] = gene.B(i)
+			}
+			return raw
+		}()))
+		for _, merge := range []EAXMerge{EAXLocal, EAXGlobal} {
+			random.Seed(42)
+			co := EdgeAssemblyCrossOver{Cost: cost, Merge: merge}
+			pop := make([]gene.Chromosome, 30)
+			for i := range pop {
+				pop[i], _ = izr.Init(n)
+			}
+			for range 2000 {
+				i, j := random.IntN(len(pop)), random.IntN(len(pop))
+				res1, _ := co.Mate(pop[i], pop[j])
+				if tourCost(res1) < tourCost(pop[i]) {
+					pop[i] = res1
+				}
+			}
+			best := math.Inf(1)
+			for _, chrm := range pop {
+				best = min(best, tourCost(chrm))
+			}
+			So(best, ShouldBeLessThan, 1.2*optimal)
+		}
+	})
+
+	Convey("invalid tours", t, func() {
+		co := EdgeAssemblyCrossOver{Cost: cost}
+		chrm1 := newChromosome([]gene.B{0, 1, 1, 3})
+		chrm2 := newChromosome([]gene.B{0, 1, 2, 3})
+		res1, res2 := co.Mate(chrm1, chrm2)
+		So(res1, ShouldResemble, chrm1)
+		So(res2, ShouldResemble, chrm2)
+
+		res1, _ = EdgeAssemblyCrossOver{}.Mate(chrm2, chrm2)
+		So(res1, ShouldResemble, chrm2)
+	})
+}
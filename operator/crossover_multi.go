@@ -0,0 +1,241 @@
+package operator
+
+import (
+	"slices"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/random"
+)
+
+// MultiParentCrossOver is an optional interface of a crossover, used by the engine to mate N parents into M children
+// Mate is still used when the crossover is combined with other ones (eg.: in a MultiCrossOver)
+type MultiParentCrossOver interface {
+	CrossOver
+	// Arity returns the number of parents expected and the number of children produced by MateN
+	Arity() (parents, children int)
+	// MateN mates all parents and returns the children
+	MateN(parents []gene.Chromosome) []gene.Chromosome
+}
+
+// ------------------------------
+
+// DiagonalCrossOver cuts N parents at N-1 common random points,
+// the child #i takes the segment #k from the parent #(i+k)%N (N children)
+// eg.: with 3 parents
+//   - parents:  AA.AAA.A  BB.BBB.B  CC.CCC.C
+//   - children: AA.BBB.C  BB.CCC.A  CC.AAA.B
+type DiagonalCrossOver struct {
+	Parents int // Number of parents (default: 3)
+}
+
+func (co DiagonalCrossOver) Arity() (int, int) {
+	n := nbParents(co.Parents)
+	return n, n
+}
+
+func (co DiagonalCrossOver) MateN(parents []gene.Chromosome) []gene.Chromosome {
+	if parents[0].IsPacked() {
+		return unpackedMateN(co, parents)
+	}
+	size := parents[0].Len()
+	cuts := append(randomCuts(size, len(parents)-1), size)
+	children := make([]gene.Chromosome, len(parents))
+	for i := range parents {
+		children[i] = parents[i].New()
+		start := 0
+		for k, end := range cuts {
+			copy(children[i].Raw[start:end], parents[(i+k)%len(parents)].Raw[start:end])
+			start = end
+		}
+	}
+	return children
+}
+
+func (co DiagonalCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	return mateTwo(co, chrm1, chrm2)
+}
+
+// ------------------------------
+
+// ScanningCrossOver builds each base of a child by scanning the bases of N parents at the same position
+// * uniform scanning (default): the base of a random parent (N children)
+// * occurrence scanning: the most frequent base (ties are randomly broken) (1 child)
+type ScanningCrossOver struct {
+	Parents    int  // Number of parents (default: 3)
+	Occurrence bool // Use the occurrence scanning
+}
+
+func (co ScanningCrossOver) Arity() (int, int) {
+	n := nbParents(co.Parents)
+	if co.Occurrence {
+		return n, 1
+	}
+	return n, n
+}
+
+func (co ScanningCrossOver) MateN(parents []gene.Chromosome) []gene.Chromosome {
+	if parents[0].IsPacked() {
+		return unpackedMateN(co, parents)
+	}
+	if co.Occurrence {
+		return []gene.Chromosome{occurrenceScanning(parents)}
+	}
+
+	children := make([]gene.Chromosome, len(parents))
+	for i := range parents {
+		children[i] = parents[i].New()
+		for j := range children[i].Raw {
+			children[i].Raw[j] = parents[random.IntN(len(parents))].Raw[j]
+		}
+	}
+	return children
+}
+
+func (co ScanningCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	return mateTwo(co, chrm1, chrm2)
+}
+
+// ------------------------------
+
+// MultiParentOrderCrossOver generalizes the order crossover to N parents (permutation)
+// The positions are cut at N-1 common random points, the child #i takes the segment #k from the parent #(i+k)%N
+// (only the values not already used), then the empty positions are filled with the missing values
+// in the order of the parent #i (N children). The number of occurrences of duplicated values is kept
+type MultiParentOrderCrossOver struct {
+	Parents int // Number of parents (default: 3)
+}
+
+func (co MultiParentOrderCrossOver) Arity() (int, int) {
+	n := nbParents(co.Parents)
+	return n, n
+}
+
+func (co MultiParentOrderCrossOver) MateN(parents []gene.Chromosome) []gene.Chromosome {
+	size := parents[0].Len()
+	cuts := append(randomCuts(size, len(parents)-1), size)
+	children := make([]gene.Chromosome, len(parents))
+	for i := range parents {
+		children[i] = multiParentOrderCrossOver(parents, i, cuts)
+	}
+	return children
+}
+
+func (co MultiParentOrderCrossOver) Mate(chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	return mateTwo(co, chrm1, chrm2)
+}
+
+// ------------------------------
+
+// mateTwo mates 2 parents using a multi-parent crossover
+func mateTwo(co MultiParentCrossOver, chrm1, chrm2 gene.Chromosome) (gene.Chromosome, gene.Chromosome) {
+	parents := []gene.Chromosome{chrm1, chrm2}
+	children := co.MateN(parents)
+	if len(children) == 1 {
+		children = append(children, co.MateN(parents)[0])
+	}
+	return children[0], children[1]
+}
+
+// unpackedMateN applies a multi-parent crossover working on the raw bases to packed chromosomes
+// (the children are packed back)
+func unpackedMateN(co MultiParentCrossOver, parents []gene.Chromosome) []gene.Chromosome {
+	unpacked := make([]gene.Chromosome, len(parents))
+	for i, parent := range parents {
+		unpacked[i] = parent.Unpack()
+	}
+	children := co.MateN(unpacked)
+	for i, child := range children {
+		children[i] = child.Pack()
+	}
+	return children
+}
+
+// nbParents returns the number of parents (default: 3, min: 2)
+func nbParents(parents int) int {
+	if parents == 0 {
+		return 3
+	}
+	return max(parents, 2)
+}
+
+// randomCuts returns k sorted distinct random points in [1 ; size[ (less if the size is too small)
+func randomCuts(size, k int) []int {
+	if size < 2 {
+		return nil
+	}
+	cuts := random.Perm(size - 1)[:min(max(k, 0), size-1)]
+	for i := range cuts {
+		cuts[i]++
+	}
+	slices.Sort(cuts)
+	return cuts
+}
+
+// occurrenceScanning builds a child using the most frequent base of all parents at each position
+func occurrenceScanning(parents []gene.Chromosome) gene.Chromosome {
+	child := parents[0].New()
+	counts := make(map[gene.B]int, len(parents))
+	var best []gene.B
+	for j := range child.Raw {
+		clear(counts)
+		var maxCount int
+		for _, parent := range parents {
+			counts[parent.Raw[j]]++
+			maxCount = max(maxCount, counts[parent.Raw[j]])
+		}
+		best = best[:0]
+		for value, count := range counts {
+			if count == maxCount {
+				best = append(best, value)
+			}
+		}
+		slices.Sort(best) // map order is random, keep the draw reproducible
+		child.Raw[j] = best[random.IntN(len(best))]
+	}
+	return child
+}
+
+// multiParentOrderCrossOver builds the child #i
+// parents:   1 2 3 4 5 6  |  6 5 4 3 2 1  |  2 4 6 1 3 5
+// cuts:          2     4
+// child #0:  1 2 4 3 . 5  (segment #0 from parent #0, #1 from #1, #2 from #2 where 3 is already used)
+// filled:    1 2 4 3 6 5  (missing values in the order of parent #0)
+func multiParentOrderCrossOver(parents []gene.Chromosome, i int, cuts []int) gene.Chromosome {
+	child := parents[i].New()
+	used := make([]bool, child.Len())
+
+	// Values still available (multiset of the parent #i)
+	available := make(map[gene.B]int, child.Len())
+	for _, value := range parents[i].Raw {
+		available[value]++
+	}
+
+	// Copy segments
+	start := 0
+	for k, end := range cuts {
+		parent := parents[(i+k)%len(parents)]
+		for j := start; j < end; j++ {
+			if value := parent.Raw[j]; available[value] > 0 {
+				child.Raw[j] = value
+				used[j] = true
+				available[value]--
+			}
+		}
+		start = end
+	}
+
+	// Fill the empty positions
+	j := 0
+	for _, value := range parents[i].Raw {
+		if available[value] == 0 {
+			continue
+		}
+		available[value]--
+		for used[j] {
+			j++
+		}
+		child.Raw[j] = value
+		used[j] = true
+	}
+	return child
+}
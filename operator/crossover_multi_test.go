@@ -0,0 +1,110 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/random"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMultiParentCrossOvers(t *testing.T) {
+	parents := []gene.Chromosome{
+		newChromosome([]gene.B{'A', 'A', 'A', 'A', 'A', 'A'}),
+		newChromosome([]gene.B{'B', 'B', 'B', 'B', 'B', 'B'}),
+		newChromosome([]gene.B{'C', 'C', 'C', 'C', 'C', 'C'}),
+	}
+
+	Convey("arity", t, func() {
+		for _, co := range []MultiParentCrossOver{DiagonalCrossOver{}, ScanningCrossOver{}, MultiParentOrderCrossOver{}} {
+			nbParents, nbChildren := co.Arity()
+			So(nbParents, ShouldEqual, 3)
+			So(nbChildren, ShouldEqual, 3)
+		}
+		nbParents, nbChildren := ScanningCrossOver{Parents: 5, Occurrence: true}.Arity()
+		So(nbParents, ShouldEqual, 5)
+		So(nbChildren, ShouldEqual, 1)
+		nbParents, _ = DiagonalCrossOver{Parents: 1}.Arity()
+		So(nbParents, ShouldEqual, 2)
+	})
+
+	Convey("diagonal crossover", t, func() {
+		children := DiagonalCrossOver{}.MateN(parents)
+		So(children, ShouldHaveLength, 3)
+		for i, child := range children {
+			So(child.Raw[0], ShouldEqual, 'A'+i)
+			So(child.Raw[5], ShouldEqual, 'A'+(i+2)%3)
+		}
+		// Each position comes from a different parent in each child
+		for j := range 6 {
+			So(children[0].Raw[j], ShouldNotEqual, children[1].Raw[j])
+			So(children[1].Raw[j], ShouldNotEqual, children[2].Raw[j])
+		}
+
+		res1, res2 := DiagonalCrossOver{}.Mate(parents[0], parents[1])
+		So(res1.Raw[0], ShouldEqual, 'A')
+		So(res2.Raw[0], ShouldEqual, 'B')
+	})
+
+	Convey("scanning crossover", t, func() {
+		Convey("when uniform", func() {
+			for _, child := range (ScanningCrossOver{}).MateN(parents) {
+				for _, b := range child.Raw {
+					So(b, ShouldBeIn, []gene.B{'A', 'B', 'C'})
+				}
+			}
+		})
+
+		Convey("when occurrence", func() {
+			voters := []gene.Chromosome{
+				newChromosome([]gene.B{1, 1, 0, 0}),
+				newChromosome([]gene.B{1, 0, 0, 1}),
+				newChromosome([]gene.B{0, 1, 0, 1}),
+			}
+			children := ScanningCrossOver{Occurrence: true}.MateN(voters)
+			So(children, ShouldHaveLength, 1)
+			So(children[0].Raw, ShouldResemble, []gene.B{1, 1, 0, 1})
+
+			res1, res2 := ScanningCrossOver{Occurrence: true}.Mate(voters[0], voters[1])
+			So(res1.Raw[0], ShouldEqual, 1)
+			So(res2.Raw[0], ShouldEqual, 1)
+		})
+	})
+
+	Convey("multi-parent order crossover", t, func() {
+		perms := []gene.Chromosome{
+			newChromosome([]gene.B{1, 2, 3, 4, 5, 6}),
+			newChromosome([]gene.B{6, 5, 4, 3, 2, 1}),
+			newChromosome([]gene.B{2, 4, 6, 1, 3, 5}),
+		}
+		So(multiParentOrderCrossOver(perms, 0, []int{2, 4, 6}).Raw, ShouldResemble, []gene.B{1, 2, 4, 3, 6, 5})
+		So(multiParentOrderCrossOver(perms, 1, []int{2, 4, 6}).Raw, ShouldResemble, []gene.B{6, 5, 4, 1, 3, 2})
+
+		Convey("when valid permutations", func() {
+			izr := gene.MultisetPermutationInitializer{Counts: []int{2, 3, 1, 4}}
+			for range 20 {
+				parents := make([]gene.Chromosome, 2+random.IntN(4))
+				for i := range parents {
+					parents[i], _ = izr.Init(0)
+				}
+				for _, child := range (MultiParentOrderCrossOver{}).MateN(parents) {
+					So(izr.Validate(child), ShouldBeNil)
+				}
+			}
+		})
+	})
+
+	Convey("random cuts", t, func() {
+		cuts := randomCuts(10, 4)
+		So(cuts, ShouldHaveLength, 4)
+		for i, cut := range cuts {
+			So(cut, ShouldBeBetweenOrEqual, 1, 9)
+			if i > 0 {
+				So(cut, ShouldBeGreaterThan, cuts[i-1])
+			}
+		}
+		So(randomCuts(3, 5), ShouldHaveLength, 2)
+		So(randomCuts(1, 5), ShouldBeEmpty)
+	})
+}
@@ -97,6 +97,79 @@ func TestCrossOvers(t *testing.T) {
 			So(res1.Raw, ShouldResemble, []gene.B{1, 1, 0, 1, 1, 1, 0, 1})
 			So(res2.Raw, ShouldResemble, []gene.B{0, 0, 1, 0, 0, 0, 1, 0})
 		})
+
+		Convey("when bias", func() {
+			random.Seed(42)
+			count := func(chrm gene.Chromosome) (total int) {
+				for _, b := range chrm.Raw {
+					total += int(b)
+				}
+				return
+			}
+			res1, res2 := UniformCrossOver{Bias: 0.999}.Mate(chrm1, chrm2)
+			So(count(res1), ShouldBeGreaterThanOrEqualTo, 7)
+			So(count(res2), ShouldBeLessThanOrEqualTo, 1)
+			res1, _ = UniformCrossOver{Bias: 0.001}.Mate(chrm1, chrm2)
+			So(count(res1), ShouldBeLessThanOrEqualTo, 1)
+		})
+	})
+
+	Convey("generalized crossovers", t, func() {
+		random.Seed(42)
+		chrm1 := newChromosome([]gene.B{1, 1, 1, 1, 1, 1, 1, 1})
+		chrm2 := newChromosome([]gene.B{0, 0, 0, 0, 0, 0, 0, 0})
+		switches := func(chrm gene.Chromosome) int {
+			var total int
+			for i := 1; i < chrm.Len(); i++ {
+				if chrm.Raw[i] != chrm.Raw[i-1] {
+					total++
+				}
+			}
+			return total
+		}
+		complementary := func(res1, res2 gene.Chromosome) {
+			for i := range res1.Raw {
+				So(res1.Raw[i]+res2.Raw[i], ShouldEqual, 1)
+			}
+		}
+
+		Convey("when k points", func() {
+			for k := range 9 {
+				res1, res2 := KPointsCrossOver{K: k}.Mate(chrm1, chrm2)
+				So(switches(res1), ShouldEqual, min(max(k, 1), 7))
+				So(res1.Raw[0], ShouldEqual, 1)
+				complementary(res1, res2)
+			}
+			res1, _ := KPointsCrossOver{K: 3}.Mate(newChromosome([]gene.B{1}), newChromosome([]gene.B{0}))
+			So(res1.Raw, ShouldResemble, []gene.B{1})
+		})
+
+		Convey("when shuffle", func() {
+			for range 20 {
+				res1, res2 := ShuffleCrossOver{}.Mate(chrm1, chrm2)
+				So(res1.Raw[0]+res2.Raw[0], ShouldEqual, 1)
+				So(res1.Distance(chrm1), ShouldBeBetweenOrEqual, 1, 7)
+				complementary(res1, res2)
+			}
+		})
+
+		Convey("when segmented", func() {
+			res1, res2 := SegmentedCrossOver{SwitchRate: 0.999}.Mate(chrm1, chrm2)
+			So(res1.Raw, ShouldResemble, []gene.B{1, 0, 1, 0, 1, 0, 1, 0})
+			complementary(res1, res2)
+			res1, _ = SegmentedCrossOver{SwitchRate: 0.001}.Mate(chrm1, chrm2)
+			So(res1.Raw, ShouldResemble, chrm1.Raw)
+		})
+
+		Convey("when half uniform", func() {
+			chrm1 := newChromosome([]gene.B{1, 1, 1, 1, 1, 1, 0, 0})
+			chrm2 := newChromosome([]gene.B{0, 0, 0, 0, 1, 1, 0, 0})
+			res1, res2 := HalfUniformCrossOver{}.Mate(chrm1, chrm2)
+			So(res1.Distance(chrm1), ShouldEqual, 2)
+			So(res1.Distance(chrm2), ShouldEqual, 2)
+			So(res2.Distance(chrm2), ShouldEqual, 2)
+			So(res1.Raw[4:], ShouldResemble, []gene.B{1, 1, 0, 0})
+		})
 	})
 
 	Convey("davis' order crossover", t, func() {
@@ -187,6 +260,196 @@ func TestCrossOvers(t *testing.T) {
 		So(partiallyMatchCrossOver(chrm2, chrm1, pos1, pos2).Raw, ShouldResemble, []gene.B{3, 7, 5, 1, 6, 4, 2, 8})
 	})
 
+	Convey("partially matched crossover with duplicated values", t, func() {
+		chrm1 := newChromosome([]gene.B{0, 0, 1, 1, 2, 2})
+		chrm2 := newChromosome([]gene.B{1, 0, 2, 1, 0, 2})
+
+		// Labels: chrm1 = 0.0 0.1 1.0 1.1 2.0 2.1 ; chrm2 = 1.0 0.0 2.0 1.1 0.1 2.1
+		So(partiallyMatchCrossOver(chrm1, chrm2, 1, 4).Raw, ShouldResemble, []gene.B{0, 0, 2, 1, 1, 2})
+		So(partiallyMatchCrossOver(chrm2, chrm1, 1, 4).Raw, ShouldResemble, []gene.B{2, 0, 1, 1, 0, 2})
+	})
+
+	Convey("permutation crossovers with duplicated values", t, func() {
+		izr := gene.MultisetPermutationInitializer{Counts: []int{3, 2, 4, 1}}
+		crossovers := []CrossOver{
+			PartiallyMatchCrossOver{}, DavisOrderCrossOver{}, UniformOrderCrossOver{},
+			CycleCrossOver{}, OrderBasedCrossOver{}, PositionBasedCrossOver{},
+		}
+		for range 50 {
+			chrm1, _ := izr.Init(0)
+			chrm2, _ := izr.Init(0)
+			for _, co := range crossovers {
+				res1, res2 := co.Mate(chrm1, chrm2)
+				So(izr.Validate(res1), ShouldBeNil)
+				So(izr.Validate(res2), ShouldBeNil)
+			}
+		}
+	})
+
+	Convey("cycle crossover", t, func() {
+		chrm1 := newChromosome([]gene.B{1, 2, 3, 4, 5, 6, 7, 8})
+		chrm2 := newChromosome([]gene.B{8, 5, 2, 1, 3, 6, 4, 7})
+
+		res1, res2 := CycleCrossOver{}.Mate(chrm1, chrm2)
+		So(res1.Raw, ShouldResemble, []gene.B{1, 5, 2, 4, 3, 6, 7, 8})
+		So(res2.Raw, ShouldResemble, []gene.B{8, 2, 3, 1, 5, 6, 4, 7})
+	})
+
+	Convey("order based crossover", t, func() {
+		chrm1 := newChromosome([]gene.B{1, 2, 3, 4, 5, 6, 7, 8})
+		chrm2 := newChromosome([]gene.B{2, 4, 6, 8, 7, 5, 3, 1})
+
+		positions := []int{1, 2, 5}
+		So(orderBasedCrossOver(chrm1, chrm2, positions).Raw, ShouldResemble, []gene.B{1, 2, 3, 4, 6, 5, 7, 8})
+		So(orderBasedCrossOver(chrm2, chrm1, positions).Raw, ShouldResemble, []gene.B{2, 4, 3, 8, 7, 5, 6, 1})
+	})
+
+	Convey("edge recombination crossover", t, func() {
+		chrm1 := newChromosome([]gene.B{'A', 'B', 'C', 'D', 'E', 'F'})
+		chrm2 := newChromosome([]gene.B{'B', 'D', 'C', 'A', 'E', 'F'})
+
+		res1, res2 := EdgeRecombinationCrossOver{}.Mate(chrm1, chrm2)
+		So(res1.Raw[0], ShouldEqual, 'A')
+		So(res1.Raw[1], ShouldBeIn, []gene.B{'F', 'C', 'E'})
+		So(res2.Raw[0], ShouldEqual, 'B')
+	})
+
+	Convey("random positions", t, func() {
+		for range 20 {
+			unselected, selected := randomPositions(6)
+			So(len(selected), ShouldBeBetweenOrEqual, 1, 5)
+			So(len(unselected)+len(selected), ShouldEqual, 6)
+		}
+		unselected, selected := randomPositions(1)
+		So(unselected, ShouldResemble, []int{0})
+		So(selected, ShouldBeEmpty)
+	})
+
+	Convey("permutation crossovers always yield valid permutations", t, func() {
+		izr := gene.PermutationInitializer{}
+		crossovers := []CrossOver{
+			DavisOrderCrossOver{}, UniformOrderCrossOver{}, PartiallyMatchCrossOver{},
+			EdgeRecombinationCrossOver{}, CycleCrossOver{}, OrderBasedCrossOver{}, PositionBasedCrossOver{},
+		}
+		for _, size := range []int{1, 2, 5, 30} {
+			for range 20 {
+				chrm1, _ := izr.Init(size)
+				chrm2, _ := izr.Init(size)
+				for _, co := range crossovers {
+					res1, res2 := co.Mate(chrm1, chrm2)
+					So(izr.Validate(res1), ShouldBeNil)
+					So(izr.Validate(res2), ShouldBeNil)
+				}
+			}
+		}
+	})
+
+	Convey("cut and splice crossover", t, func() {
+		chrm1 := newChromosome([]gene.B{1, 2, 3, 4, 5})
+		chrm2 := newChromosome([]gene.B{11, 12, 13, 14, 15, 16})
+
+		res1, res2 := cutAndSplice(chrm1, chrm2, 3, 1)
+		So(res1, ShouldResemble, newChromosome([]gene.B{1, 2, 3, 12, 13, 14, 15, 16}))
+		So(res2, ShouldResemble, newChromosome([]gene.B{11, 4, 5}))
+		So(chrm1.Raw, ShouldResemble, []gene.B{1, 2, 3, 4, 5}) // unchanged
+
+		Convey("when bounds", func() {
+			for range 20 {
+				res1, res2 := CutAndSpliceCrossOver{MinLen: 4, MaxLen: 7}.Mate(chrm1, chrm2)
+				So(res1.Len()+res2.Len(), ShouldEqual, 11)
+				So(res1.Len(), ShouldBeBetweenOrEqual, 4, 7)
+				So(res2.Len(), ShouldBeBetweenOrEqual, 4, 7)
+			}
+		})
+	})
+
+	Convey("messy crossover", t, func() {
+		chrm1 := newChromosome([]gene.B{1, 2, 3, 4, 5})
+		chrm2 := newChromosome([]gene.B{11, 12, 13, 14, 15, 16})
+
+		res1, res2 := messyCrossOver(chrm1, chrm2, 1, 3, 2, 5)
+		So(res1, ShouldResemble, newChromosome([]gene.B{1, 13, 14, 15, 4, 5}))
+		So(res2, ShouldResemble, newChromosome([]gene.B{11, 12, 2, 3, 16}))
+		So(chrm2.Raw, ShouldResemble, []gene.B{11, 12, 13, 14, 15, 16}) // unchanged
+
+		Convey("when bounds", func() {
+			for range 20 {
+				res1, res2 := MessyCrossOver{MaxLen: 6}.Mate(chrm1, chrm2)
+				So(res1.Len()+res2.Len(), ShouldEqual, 11)
+				So(res1.Len(), ShouldBeBetweenOrEqual, 1, 6)
+				So(res2.Len(), ShouldBeBetweenOrEqual, 1, 6)
+			}
+		})
+	})
+
+	Convey("bit crossovers", t, func() {
+		chrm1 := gene.NewChromosomeBits(130)
+		chrm2 := gene.NewChromosomeBits(130)
+		for i := range 130 {
+			chrm1.Bits.Set(i, true)
+		}
+
+		Convey("when points mask", func() {
+			mask := pointsMask([]int{2, 4, 6})
+			So(mask(0)&0xff, ShouldEqual, 0b00110011) // bit #0 is the lowest
+			So(mask(1), ShouldEqual, 0)
+
+			mask = pointsMask([]int{70})
+			So(mask(0), ShouldEqual, ^uint64(0))
+			So(mask(1), ShouldEqual, 0b111111)
+			So(mask(2), ShouldEqual, 0)
+		})
+
+		Convey("when mask crossover", func() {
+			res1, res2 := bitMaskCrossOver(chrm1, chrm2, pointsMask([]int{70}))
+			So(res1.Bits.Count(), ShouldEqual, 70)
+			So(res2.Bits.Count(), ShouldEqual, 60)
+			So(res1.Bits.Get(69), ShouldBeTrue)
+			So(res1.Bits.Get(70), ShouldBeFalse)
+			So(chrm1.Bits.Count(), ShouldEqual, 130) // unchanged
+		})
+
+		Convey("when random", func() {
+			for _, co := range []CrossOver{BitOnePointCrossOver{}, BitTwoPointsCrossOver{}, BitUniformCrossOver{}} {
+				res1, res2 := co.Mate(chrm1, chrm2)
+				So(res1.Bits.Count()+res2.Bits.Count(), ShouldEqual, 130)
+				So(res1.Bits.Distance(res2.Bits), ShouldEqual, 130)
+			}
+		})
+
+		Convey("when raw crossovers", func() {
+			for _, co := range []CrossOver{
+				OnePointCrossOver{}, TwoPointsCrossOver{}, ThreePointsCrossOver{}, KPointsCrossOver{K: 4},
+				UniformCrossOver{}, ShuffleCrossOver{}, SegmentedCrossOver{}, HalfUniformCrossOver{},
+				DiagonalCrossOver{Parents: 2},
+			} {
+				res1, res2 := co.Mate(chrm1, chrm2)
+				So(res1.IsPacked(), ShouldBeTrue)
+				So(res2.IsPacked(), ShouldBeTrue)
+				So(res1.Bits.Count()+res2.Bits.Count(), ShouldEqual, 130)
+				So(res1.Bits.Distance(res2.Bits), ShouldEqual, 130)
+			}
+
+			children := ScanningCrossOver{}.MateN([]gene.Chromosome{chrm1, chrm2, chrm1})
+			So(children, ShouldHaveLength, 3)
+			So(children[0].IsPacked(), ShouldBeTrue)
+			So(children[0].Len(), ShouldEqual, 130)
+
+			res1, res2 := CutAndSpliceCrossOver{}.Mate(chrm1, chrm2)
+			So(res1.IsPacked(), ShouldBeTrue)
+			So(res1.Len()+res2.Len(), ShouldEqual, 260)
+			So(res1.Bits.Count()+res2.Bits.Count(), ShouldEqual, 130)
+		})
+	})
+
+	Convey("in bounds", t, func() {
+		So(inBounds(newChromosome([]gene.B{}), 0, 0), ShouldBeFalse)
+		So(inBounds(newChromosome([]gene.B{1}), 0, 0), ShouldBeTrue)
+		So(inBounds(newChromosome([]gene.B{1, 2, 3}), 2, 3), ShouldBeTrue)
+		So(inBounds(newChromosome([]gene.B{1, 2, 3}), 4, 0), ShouldBeFalse)
+		So(inBounds(newChromosome([]gene.B{1, 2, 3}), 1, 2), ShouldBeFalse)
+	})
+
 	Convey("multi crossovers", t, func() {
 		co1 := &AppliedCrossOver{}
 		co2 := &AppliedCrossOver{}
@@ -227,6 +490,48 @@ func TestCrossOvers(t *testing.T) {
 			So(co2.IsApplied, ShouldBeTrue)
 		})
 	})
+
+	Convey("genome crossover", t, func() {
+		newGenome := func(jobs, machines []gene.B) gene.Chromosome {
+			return gene.Genome{
+				Names:       []string{"jobs", "machines"},
+				Chromosomes: []gene.Chromosome{newChromosome(jobs), newChromosome(machines)},
+			}.Chromosome()
+		}
+		chrm1 := newGenome([]gene.B{0, 1, 2, 3}, []gene.B{0, 0, 0, 0})
+		chrm2 := newGenome([]gene.B{3, 2, 1, 0}, []gene.B{1, 1, 1, 1})
+
+		Convey("when one chromosome only", func() {
+			co := &AppliedCrossOver{}
+			res1, res2 := GenomeCrossOver{}.Use("machines", co).Mate(chrm1, chrm2)
+			So(co.IsApplied, ShouldBeTrue)
+			So(res1, ShouldResemble, chrm1)
+			So(res2, ShouldResemble, chrm2)
+		})
+
+		Convey("when each chromosome", func() {
+			res1, res2 := GenomeCrossOver{}.
+				Use("jobs", DavisOrderCrossOver{}).
+				Use("machines", UniformCrossOver{}).
+				Mate(chrm1, chrm2)
+			for _, res := range []gene.Chromosome{res1, res2} {
+				jobs, _ := res.Genome().Get("jobs")
+				So(jobs.Raw, ShouldContain, gene.B(0))
+				So(jobs.Raw, ShouldContain, gene.B(1))
+				So(jobs.Raw, ShouldContain, gene.B(2))
+				So(jobs.Raw, ShouldContain, gene.B(3))
+				machines, _ := res.Genome().Get("machines")
+				So(machines.Raw, ShouldHaveLength, 4)
+			}
+			So(chrm1, ShouldResemble, newGenome([]gene.B{0, 1, 2, 3}, []gene.B{0, 0, 0, 0}))
+		})
+
+		Convey("when not a genome", func() {
+			co := &AppliedCrossOver{}
+			_, _ = GenomeCrossOver{}.Use("jobs", co).Mate(gene.Chromosome{}, gene.Chromosome{})
+			So(co.IsApplied, ShouldBeFalse)
+		})
+	})
 }
 
 func TestFinder(t *testing.T) {
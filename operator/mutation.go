@@ -1,6 +1,10 @@
 package operator
 
 import (
+	"maps"
+	"math"
+	"slices"
+
 	"github.com/sbiemont/galgogene/gene"
 	"github.com/sbiemont/galgogene/random"
 )
@@ -18,14 +22,18 @@ type Mutation interface {
 
 // ------------------------------
 
-// UniqueMutation selects one unique bit and flips its value (using the max value)
+// UniqueMutation selects one unique bit and flips its value (using the max value or the allowed values)
 type UniqueMutation struct{}
 
 // Mutate a unique bit in the gene
 func (UniqueMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
 	i := random.IntN(chrm.Len())
 	result := chrm.Clone()
-	result.Raw[i] = result.Rand()
+	if chrm.IsPacked() {
+		result.Bits.Set(i, result.Rand() > 0)
+		return result
+	}
+	result.Raw[i] = result.RandAt(i)
 	return result
 }
 
@@ -34,20 +42,82 @@ func (UniqueMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
 // UniformMutation defines a random mutation of bases
 type UniformMutation struct{}
 
-// Mutate each bit with a probability of 50%
+// Mutate each bit with a probability of 50% (using the max value or the allowed values)
 func (UniformMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
-	return mutate(chrm, 0.5, func(b gene.Chromosome, _ int) gene.B {
-		return b.Rand()
+	if chrm.IsPacked() {
+		return BitUniformMutation{}.Mutate(chrm)
+	}
+	return mutate(chrm, 0.5, func(b gene.Chromosome, i int) gene.B {
+		return b.RandAt(i)
 	})
 }
 
 // ------------------------------
 
+// Boundary defines how a value out of the allowed range is brought back
+type Boundary int
+
+const (
+	BoundaryClamp   Boundary = iota // Use the nearest bound
+	BoundaryWrap                    // Continue from the other bound
+	BoundaryReflect                 // Bounce back on the bound
+)
+
+// CreepMutation selects one unique base and moves its value by ±[1 ; Step] in its allowed values
+// Without spec, the allowed values are [0 ; max value], otherwise, values are moved in the order of the locus
+type CreepMutation struct {
+	Step     int      // Max move (default: 1)
+	Boundary Boundary // Out of bounds strategy (default: clamp)
+}
+
+func (mut CreepMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(mut, chrm)
+	}
+	i := random.IntN(chrm.Len())
+	locus := chrm.LocusAt(i)
+	index := locus.IndexOf(chrm.Raw[i])
+	if index < 0 || locus.Len() < 2 { // not allowed or no possible move
+		return chrm
+	}
+
+	step := 1 + random.IntN(max(mut.Step, 1))
+	if random.Peek(0.5) {
+		step = -step
+	}
+	result := chrm.Clone()
+	result.Raw[i] = locus.At(mut.Boundary.apply(index+step, locus.Len()))
+	return result
+}
+
+// apply the boundary strategy to bring the index back in [0 ; size[
+func (bnd Boundary) apply(index, size int) int {
+	last := size - 1
+	switch bnd {
+	case BoundaryWrap:
+		return ((index % size) + size) % size
+	case BoundaryReflect:
+		period := 2 * last
+		index = ((index % period) + period) % period
+		if index > last {
+			return period - index
+		}
+		return index
+	default:
+		return min(max(index, 0), last)
+	}
+}
+
+// ------------------------------
+
 // SwapPermutation defines a random swap of 2 bases
 type SwapPermutation struct{}
 
 // Mutate select 2 positions and swap the values
 func (SwapPermutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(SwapPermutation{}, chrm)
+	}
 	return permutation(chrm, func(in gene.Chromosome, out *gene.Chromosome, pos1, pos2 int) {
 		out.Raw[pos1] = in.Raw[pos2]
 		out.Raw[pos2] = in.Raw[pos1]
@@ -64,6 +134,9 @@ type InversionPermutation struct{}
 //   - input:  AB.CDEF.GH
 //   - output: AB.FEDC.GH
 func (InversionPermutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(InversionPermutation{}, chrm)
+	}
 	return permutation(chrm, func(in gene.Chromosome, out *gene.Chromosome, pos1, pos2 int) {
 		for i := pos1; i <= pos2; i++ {
 			out.Raw[i] = in.Raw[pos2-i+pos1]
@@ -81,6 +154,9 @@ type ScramblePermutation struct{}
 //   - input:  AB.CDEF.GH
 //   - output: AB.ECFD.GH
 func (ScramblePermutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(ScramblePermutation{}, chrm)
+	}
 	return permutation(chrm, func(in gene.Chromosome, out *gene.Chromosome, pos1, pos2 int) {
 		indexes := random.Perm(pos2 - pos1)
 		for i, index := range indexes {
@@ -91,6 +167,206 @@ func (ScramblePermutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
 
 // ------------------------------
 
+// InsertionPermutation moves one base to another random position
+// eg.:
+//   - input:  AB.C.DEF.G.H
+//   - output: ABDEFGCH
+type InsertionPermutation struct{}
+
+func (InsertionPermutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(InsertionPermutation{}, chrm)
+	}
+	if chrm.Len() < 2 {
+		return chrm
+	}
+	from, to := random.IntN(chrm.Len()), random.IntN(chrm.Len())
+	return move(chrm, from, from+1, to)
+}
+
+// ------------------------------
+
+// DisplacementPermutation moves a random segment to another random position
+// eg.:
+//   - input:  A.BCD.EFG.H
+//   - output: AEFGBCDH
+type DisplacementPermutation struct{}
+
+func (DisplacementPermutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(DisplacementPermutation{}, chrm)
+	}
+	if chrm.Len() < 2 {
+		return chrm
+	}
+	pos := random.OrderedInts(0, chrm.Len(), 2)
+	start, end := pos[0], pos[1]+1
+	return move(chrm, start, end, random.IntN(chrm.Len()-(end-start)+1))
+}
+
+// ------------------------------
+
+// SegmentExchangePermutation picks 3 points and exchanges the 2 consecutive segments (3-opt move without inversion)
+// eg.:
+//   - input:  AB.CD.EFG.H
+//   - output: ABEFGCDH
+type SegmentExchangePermutation struct{}
+
+func (SegmentExchangePermutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(SegmentExchangePermutation{}, chrm)
+	}
+	if chrm.Len() < 2 {
+		return chrm
+	}
+	pos := random.OrderedInts(0, chrm.Len()+1, 3)
+	if pos[0] == pos[1] || pos[1] == pos[2] { // empty segment, leave bases unchanged
+		return chrm
+	}
+	return move(chrm, pos[1], pos[2], pos[0])
+}
+
+// ------------------------------
+
+// TwoOptPermutation applies the best 2-opt move (inversion of a subtour) involving the edge leaving a random city of a closed tour
+// The chromosome is unchanged if no move reduces the tour cost
+// The values shall be the indexes of the cities in [0 ; n[ (see gene.Cost), the cost shall be symmetric
+type TwoOptPermutation struct {
+	Cost gene.Cost
+}
+
+func (mut TwoOptPermutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	size := chrm.Len()
+	if mut.Cost == nil || size < 4 {
+		return chrm
+	}
+
+	// Replace the edges (a, b) and (c, d) by (a, c) and (b, d)
+	cost := func(i, j int) float64 { return mut.Cost(int(chrm.Raw[i%size]), int(chrm.Raw[j%size])) }
+	i := random.IntN(size)
+	bestDelta, bestJ := 0.0, -1
+	for j := i + 2; j < i+size-1; j++ {
+		if delta := cost(i, j) + cost(i+1, j+1) - cost(i, i+1) - cost(j, j+1); delta < bestDelta {
+			bestDelta, bestJ = delta, j
+		}
+	}
+	if bestJ < 0 {
+		return chrm
+	}
+
+	// Invert the subtour [i+1 ; j] (cyclic)
+	result := chrm.Clone()
+	for k := range bestJ - i {
+		result.Raw[(i+1+k)%size] = chrm.Raw[(bestJ-k)%size]
+	}
+	return result
+}
+
+// ------------------------------
+
+// InsertMutation inserts a new random base at a random position (variable-length chromosomes)
+// The chromosome is unchanged if its size has reached MaxLen
+type InsertMutation struct {
+	MaxLen int // Max size of the chromosome (0: no limit)
+}
+
+func (mut InsertMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(mut, chrm)
+	}
+	if mut.MaxLen > 0 && chrm.Len() >= mut.MaxLen {
+		return chrm
+	}
+	pos := random.IntN(chrm.Len() + 1)
+	return chrm.NewFrom(slices.Insert(slices.Clone(chrm.Raw), pos, chrm.Rand()))
+}
+
+// ------------------------------
+
+// DeleteMutation deletes the base at a random position (variable-length chromosomes)
+// The chromosome is unchanged if its size has reached MinLen
+type DeleteMutation struct {
+	MinLen int // Min size of the chromosome (default: 1)
+}
+
+func (mut DeleteMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(mut, chrm)
+	}
+	if chrm.Len() <= max(mut.MinLen, 1) {
+		return chrm
+	}
+	pos := random.IntN(chrm.Len())
+	return chrm.NewFrom(slices.Delete(slices.Clone(chrm.Raw), pos, pos+1))
+}
+
+// ------------------------------
+
+// DuplicateMutation copies a random segment right after itself (variable-length chromosomes)
+// The segment is shortened to fit MaxLen, the chromosome is unchanged if its size has reached MaxLen
+// eg.:
+//   - input:  AB.CDE.FG
+//   - output: AB.CDE.CDE.FG
+type DuplicateMutation struct {
+	MaxLen int // Max size of the chromosome (0: no limit)
+}
+
+func (mut DuplicateMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if chrm.IsPacked() {
+		return unpackedMutate(mut, chrm)
+	}
+	if chrm.Len() == 0 {
+		return chrm
+	}
+	pos := random.OrderedInts(0, chrm.Len(), 2)
+	return duplicate(chrm, pos[0], pos[1]+1, mut.MaxLen)
+}
+
+// ------------------------------
+
+// BitFlipMutation flips each bit with a given probability (packed chromosomes only)
+// The flipped positions are drawn directly (geometric jumps), so the cost depends on the number of flips
+type BitFlipMutation struct {
+	Rate float64 // Probability to flip each bit (default: 1/size)
+}
+
+func (mut BitFlipMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	size := chrm.Len()
+	if size == 0 {
+		return chrm
+	}
+	rate := mut.Rate
+	if rate <= 0 {
+		rate = 1 / float64(size)
+	}
+
+	result := chrm.Clone()
+	logQ := math.Log1p(-min(rate, 1))
+	for i := geometric(logQ, size); i < size; i += 1 + geometric(logQ, size) {
+		result.Bits.Flip(i)
+	}
+	return result
+}
+
+// ------------------------------
+
+// BitUniformMutation defines a random mutation of bits (packed chromosomes only)
+type BitUniformMutation struct{}
+
+// Mutate each bit with a probability of 50%, word by word
+func (BitUniformMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	result := chrm.Clone()
+	words := result.Bits.Words()
+	for i := range words {
+		mask := random.Uint64()
+		words[i] = words[i]&^mask | random.Uint64()&mask
+	}
+	result.Bits.ClearTail()
+	return result
+}
+
+// ------------------------------
+
 // probaMutation is a probabilistic mutation
 type probaMutation struct {
 	rate float64  // Mutation rate
@@ -128,8 +404,69 @@ func (mm MultiMutation) Use(rate float64, mut Mutation) MultiMutation {
 	}
 }
 
+// Prepare all mutations for the given population
+func (mm MultiMutation) Prepare(pop gene.Population) Mutation {
+	mutations := make([]probaMutation, len(mm.mutations))
+	for i, m := range mm.mutations {
+		mutations[i] = probaMutation{
+			rate: m.rate,
+			mut:  Prepare(m.mut, pop),
+		}
+	}
+	return MultiMutation{
+		ApplyAll:  mm.ApplyAll,
+		mutations: mutations,
+	}
+}
+
+// ------------------------------
+
+// GenomeMutation applies a specific mutation on each named chromosome of a genome
+// The chromosomes without mutation are left unchanged, a non genome chromosome is left unchanged
+type GenomeMutation struct {
+	mutations map[string]Mutation
+}
+
+// Use the given mutation on the named chromosome
+func (gm GenomeMutation) Use(name string, mut Mutation) GenomeMutation {
+	mutations := maps.Clone(gm.mutations)
+	if mutations == nil {
+		mutations = make(map[string]Mutation)
+	}
+	mutations[name] = mut
+	return GenomeMutation{mutations: mutations}
+}
+
+func (gm GenomeMutation) Mutate(chrm gene.Chromosome) gene.Chromosome {
+	if !chrm.IsGenome() {
+		return chrm
+	}
+	gnm := chrm.Genome()
+	res := gene.Genome{Names: gnm.Names, Chromosomes: slices.Clone(gnm.Chromosomes)}
+	for i, name := range gnm.Names {
+		if mut, found := gm.mutations[name]; found {
+			res.Chromosomes[i] = mut.Mutate(gnm.Chromosomes[i])
+		}
+	}
+	return res.Chromosome()
+}
+
+// Prepare each mutation with the population of its named chromosome
+func (gm GenomeMutation) Prepare(pop gene.Population) Mutation {
+	mutations := make(map[string]Mutation, len(gm.mutations))
+	for name, mut := range gm.mutations {
+		mutations[name] = Prepare(mut, genomePopulation(pop, name))
+	}
+	return GenomeMutation{mutations: mutations}
+}
+
 // ------------------------------
 
+// unpackedMutate applies a mutation working on the raw bases to a packed chromosome (the result is packed back)
+func unpackedMutate(mut Mutation, chrm gene.Chromosome) gene.Chromosome {
+	return mut.Mutate(chrm.Unpack()).Pack()
+}
+
 // mutate inverts some bases using a mutation rate
 func mutate(chrm gene.Chromosome, rate float64, fct func(gene.Chromosome, int) gene.B) gene.Chromosome {
 	result := chrm.Clone()
@@ -150,3 +487,38 @@ func permutation(chrm gene.Chromosome, apply func(in gene.Chromosome, out *gene.
 	apply(chrm, &result, pos[0], pos[1])
 	return result
 }
+
+// move the segment [start ; end[ so that it starts at the given position in the result
+func move(chrm gene.Chromosome, start, end, to int) gene.Chromosome {
+	if start == to {
+		return chrm
+	}
+	segment := slices.Clone(chrm.Raw[start:end])
+	remaining := slices.Delete(slices.Clone(chrm.Raw), start, end)
+	return chrm.NewFrom(slices.Insert(remaining, to, segment...))
+}
+
+// duplicate inserts a copy of the segment [start ; end[ right after it
+// The copy is shortened to match the max size (no limit if maxLen is 0)
+func duplicate(chrm gene.Chromosome, start, end, maxLen int) gene.Chromosome {
+	if maxLen > 0 {
+		end = min(end, start+maxLen-chrm.Len())
+	}
+	if end <= start {
+		return chrm
+	}
+	return chrm.NewFrom(slices.Insert(slices.Clone(chrm.Raw), end, chrm.Raw[start:end]...))
+}
+
+// geometric returns the number of failures before the first success,
+// where log(1-p) is given (p is the success probability), capped to the given limit
+func geometric(logQ float64, limit int) int {
+	if logQ == math.Inf(-1) { // p = 1
+		return 0
+	}
+	n := math.Log(1-random.Percent()) / logQ
+	if n >= float64(limit) {
+		return limit
+	}
+	return int(n)
+}
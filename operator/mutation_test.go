@@ -1,6 +1,7 @@
 package operator
 
 import (
+	"math"
 	"testing"
 
 	"github.com/sbiemont/galgogene/gene"
@@ -89,6 +90,52 @@ func TestMutations(t *testing.T) {
 		So(res.Raw, ShouldResemble, []gene.B{1, 2, 5, 4, 6, 3, 7, 8})
 	})
 
+	Convey("move", t, func() {
+		chrm := newChromosome([]gene.B{'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H'})
+		So(move(chrm, 2, 3, 6).String(), ShouldEqual, "ABDEFGCH") // insertion
+		So(move(chrm, 1, 4, 4).String(), ShouldEqual, "AEFGBCDH") // displacement
+		So(move(chrm, 4, 7, 2).String(), ShouldEqual, "ABEFGCDH") // segment exchange
+		So(move(chrm, 3, 5, 3).String(), ShouldEqual, "ABCDEFGH")
+		So(chrm.String(), ShouldEqual, "ABCDEFGH")
+	})
+
+	Convey("permutations always yield valid permutations", t, func() {
+		izr := gene.PermutationInitializer{}
+		mutations := []Mutation{
+			InsertionPermutation{}, DisplacementPermutation{}, SegmentExchangePermutation{},
+			TwoOptPermutation{Cost: func(from, to int) float64 { return float64(from * to % 7) }},
+		}
+		for _, size := range []int{1, 2, 5, 30} {
+			for range 20 {
+				chrm, _ := izr.Init(size)
+				for _, mut := range mutations {
+					So(izr.Validate(mut.Mutate(chrm)), ShouldBeNil)
+				}
+			}
+		}
+	})
+
+	Convey("2-opt permutation", t, func() {
+		cost := func(from, to int) float64 { return math.Abs(float64(from - to)) }
+		tourCost := func(chrm gene.Chromosome) float64 {
+			var total float64
+			for i, city := range chrm.Raw {
+				total += cost(int(city), int(chrm.Raw[(i+1)%chrm.Len()]))
+			}
+			return total
+		}
+		chrm := newChromosome([]gene.B{0, 2, 1, 3})
+		So(tourCost(chrm), ShouldEqual, 8)
+		for range 10 {
+			So(tourCost(TwoOptPermutation{Cost: cost}.Mutate(chrm)), ShouldEqual, 6)
+		}
+		So(chrm.Raw, ShouldResemble, []gene.B{0, 2, 1, 3})
+
+		// Already optimal
+		chrm = newChromosome([]gene.B{0, 1, 2, 3, 4, 5})
+		So(TwoOptPermutation{Cost: cost}.Mutate(chrm), ShouldResemble, chrm)
+	})
+
 	Convey("unique mutation", t, func() {
 		random.Seed(42)
 		chrm := newChromosome([]gene.B{1, 2, 3, 4, 5, 6, 7, 8})
@@ -103,6 +150,137 @@ func TestMutations(t *testing.T) {
 		So(res.Raw, ShouldResemble, []gene.B{16, 2, 12, 31, 25, 6, 7, 8})
 	})
 
+	Convey("mutations with spec", t, func() {
+		spec := gene.UniformSpec(gene.Alphabet("AB"))
+		chrm := gene.NewChromosomeSpec(20, spec)
+		for i := range chrm.Raw {
+			chrm.Raw[i] = 'A'
+		}
+		So(spec.Validate(UniqueMutation{}.Mutate(chrm)), ShouldBeNil)
+		So(spec.Validate(UniformMutation{}.Mutate(chrm)), ShouldBeNil)
+		So(spec.Validate(CreepMutation{Step: 3, Boundary: BoundaryReflect}.Mutate(chrm)), ShouldBeNil)
+	})
+
+	Convey("creep mutation", t, func() {
+		chrm := gene.NewChromosomeSpec(1, gene.UniformSpec(gene.Range(10, 20)))
+		chrm.Raw[0] = 15
+		for range 20 {
+			res := CreepMutation{Step: 2}.Mutate(chrm)
+			So(res.Raw[0], ShouldBeIn, []gene.B{13, 14, 16, 17})
+		}
+		So(chrm.Raw[0], ShouldEqual, 15) // unchanged
+
+		// Not allowed value
+		chrm.Raw[0] = 42
+		So(CreepMutation{}.Mutate(chrm), ShouldResemble, chrm)
+
+		// Without spec
+		chrm = newChromosome([]gene.B{0})
+		for range 20 {
+			res := CreepMutation{}.Mutate(chrm)
+			So(res.Raw[0], ShouldBeIn, []gene.B{0, 1})
+		}
+	})
+
+	Convey("boundary", t, func() {
+		// 5 values: 0 1 2 3 4
+		So(BoundaryClamp.apply(-2, 5), ShouldEqual, 0)
+		So(BoundaryClamp.apply(6, 5), ShouldEqual, 4)
+		So(BoundaryClamp.apply(3, 5), ShouldEqual, 3)
+		So(BoundaryWrap.apply(-2, 5), ShouldEqual, 3)
+		So(BoundaryWrap.apply(6, 5), ShouldEqual, 1)
+		So(BoundaryReflect.apply(-2, 5), ShouldEqual, 2)
+		So(BoundaryReflect.apply(6, 5), ShouldEqual, 2)
+		So(BoundaryReflect.apply(11, 5), ShouldEqual, 3)
+		So(BoundaryReflect.apply(3, 5), ShouldEqual, 3)
+	})
+
+	Convey("bit flip mutation", t, func() {
+		chrm := gene.NewChromosomeBits(1000)
+
+		res := BitFlipMutation{Rate: 1}.Mutate(chrm)
+		So(res.Bits.Count(), ShouldEqual, 1000)
+		So(chrm.Bits.Count(), ShouldEqual, 0) // unchanged
+
+		res = BitFlipMutation{Rate: 0.1}.Mutate(chrm)
+		So(res.Bits.Count(), ShouldBeBetween, 50, 150)
+
+		res = BitFlipMutation{}.Mutate(chrm) // 1 flip expected
+		So(res.Bits.Count(), ShouldBeLessThan, 10)
+	})
+
+	Convey("bit uniform mutation", t, func() {
+		chrm := gene.NewChromosomeBits(1000)
+		res := BitUniformMutation{}.Mutate(chrm)
+		So(res.Bits.Count(), ShouldBeBetween, 150, 350) // 25% of flips expected
+		So(res.Bits.Words()[15]>>40, ShouldEqual, 0)    // tail cleared
+		So(chrm.Bits.Count(), ShouldEqual, 0)           // unchanged
+	})
+
+	Convey("raw mutations on a packed chromosome", t, func() {
+		chrm := gene.NewChromosomeBits(100)
+		for i := range 50 {
+			chrm.Bits.Set(i, true)
+		}
+
+		res := UniqueMutation{}.Mutate(chrm)
+		So(res.IsPacked(), ShouldBeTrue)
+		So(res.Distance(chrm), ShouldBeLessThanOrEqualTo, 1)
+
+		res = UniformMutation{}.Mutate(chrm)
+		So(res.IsPacked(), ShouldBeTrue)
+		So(res.Distance(chrm), ShouldBeBetween, 5, 45) // 25% of flips expected
+
+		for _, mut := range []Mutation{
+			SwapPermutation{}, InversionPermutation{}, ScramblePermutation{},
+			InsertionPermutation{}, DisplacementPermutation{}, SegmentExchangePermutation{},
+		} {
+			res = mut.Mutate(chrm)
+			So(res.IsPacked(), ShouldBeTrue)
+			So(res.Bits.Count(), ShouldEqual, 50)
+		}
+
+		res = InsertMutation{}.Mutate(chrm)
+		So(res.IsPacked(), ShouldBeTrue)
+		So(res.Len(), ShouldEqual, 101)
+		So(chrm.Bits.Count(), ShouldEqual, 50) // unchanged
+	})
+
+	Convey("geometric", t, func() {
+		So(geometric(math.Inf(-1), 10), ShouldEqual, 0)
+		So(geometric(math.Log1p(-1e-12), 10), ShouldBeLessThanOrEqualTo, 10)
+	})
+
+	Convey("insert mutation", t, func() {
+		chrm := newChromosome([]gene.B{1, 2, 3})
+		res := InsertMutation{}.Mutate(chrm)
+		So(res.Len(), ShouldEqual, 4)
+		So(chrm.Raw, ShouldResemble, []gene.B{1, 2, 3}) // unchanged
+
+		So(InsertMutation{MaxLen: 3}.Mutate(chrm), ShouldResemble, chrm)
+	})
+
+	Convey("delete mutation", t, func() {
+		chrm := newChromosome([]gene.B{1, 2, 3})
+		res := DeleteMutation{}.Mutate(chrm)
+		So(res.Len(), ShouldEqual, 2)
+		So(chrm.Raw, ShouldResemble, []gene.B{1, 2, 3}) // unchanged
+
+		So(DeleteMutation{MinLen: 3}.Mutate(chrm), ShouldResemble, chrm)
+		So(DeleteMutation{}.Mutate(newChromosome([]gene.B{1})).Raw, ShouldResemble, []gene.B{1})
+	})
+
+	Convey("duplicate mutation", t, func() {
+		chrm := newChromosome([]gene.B{1, 2, 3, 4, 5})
+		So(duplicate(chrm, 1, 3, 0), ShouldResemble, newChromosome([]gene.B{1, 2, 3, 2, 3, 4, 5}))
+		So(duplicate(chrm, 1, 4, 7), ShouldResemble, newChromosome([]gene.B{1, 2, 3, 2, 3, 4, 5}))
+		So(duplicate(chrm, 1, 4, 5), ShouldResemble, chrm)
+		So(chrm.Raw, ShouldResemble, []gene.B{1, 2, 3, 4, 5}) // unchanged
+
+		res := DuplicateMutation{MaxLen: 8}.Mutate(chrm)
+		So(res.Len(), ShouldBeBetweenOrEqual, 6, 8)
+	})
+
 	Convey("multi mutations", t, func() {
 		mut1 := &AppliedMutation{}
 		mut2 := &AppliedMutation{}
@@ -143,4 +321,31 @@ func TestMutations(t *testing.T) {
 			So(mut2.IsApplied, ShouldBeTrue)
 		})
 	})
+
+	Convey("genome mutation", t, func() {
+		chrm := gene.Genome{
+			Names:       []string{"jobs", "machines"},
+			Chromosomes: []gene.Chromosome{newChromosome([]gene.B{0, 1, 2, 3}), newChromosome([]gene.B{0, 0, 0, 0})},
+		}.Chromosome()
+		mut := &AppliedMutation{}
+
+		Convey("when one chromosome only", func() {
+			res := GenomeMutation{}.Use("jobs", UniformMutation{}).Use("other", mut).Mutate(chrm)
+			So(mut.IsApplied, ShouldBeFalse)
+			jobs, _ := res.Genome().Get("jobs")
+			So(jobs.Raw, ShouldHaveLength, 4)
+			machines, _ := res.Genome().Get("machines")
+			So(machines.Raw, ShouldResemble, []gene.B{0, 0, 0, 0})
+		})
+
+		Convey("when applied", func() {
+			_ = GenomeMutation{}.Use("machines", mut).Mutate(chrm)
+			So(mut.IsApplied, ShouldBeTrue)
+		})
+
+		Convey("when not a genome", func() {
+			_ = GenomeMutation{}.Use("", mut).Mutate(newChromosome([]gene.B{1, 2}))
+			So(mut.IsApplied, ShouldBeFalse)
+		})
+	})
 }
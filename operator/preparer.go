@@ -0,0 +1,69 @@
+package operator
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/random"
+)
+
+// Preparer is an optional interface of an operator (eg.: a Preparer[Selection] is a selection),
+// used by the engine to compute the per-generation data once (cumulative sums, sorted indexes...)
+// The prepared operator is then used for the whole generation
+type Preparer[T any] interface {
+	// Prepare returns the operator to be used with the given population
+	Prepare(pop gene.Population) T
+}
+
+// Prepare returns the operator prepared for the given population (or the operator itself if it is not a Preparer)
+func Prepare[T any](op T, pop gene.Population) T {
+	if prp, ok := any(op).(Preparer[T]); ok {
+		return prp.Prepare(pop)
+	}
+	return op
+}
+
+// ------------------------------
+
+// cumulativeSelection selects an individual with a probability proportional to its weight
+// using the cumulative sums of the weights and a binary search (O(log n) per selection)
+type cumulativeSelection struct {
+	individuals []gene.Individual
+	cumulative  []float64
+}
+
+// newCumulativeSelection computes the cumulative sums of the weights of the individuals
+func newCumulativeSelection(individuals []gene.Individual, weight func(i int) float64) cumulativeSelection {
+	cumulative := make([]float64, len(individuals))
+	var total float64
+	for i := range individuals {
+		total += weight(i)
+		cumulative[i] = total
+	}
+	return cumulativeSelection{
+		individuals: individuals,
+		cumulative:  cumulative,
+	}
+}
+
+func (sel cumulativeSelection) Select(gene.Population) (gene.Individual, error) {
+	n := len(sel.individuals)
+	if n == 0 {
+		return gene.Individual{}, errors.New("selection: empty population")
+	}
+	target := random.Percent() * sel.cumulative[n-1]
+	i := sort.SearchFloat64s(sel.cumulative, target) // first partial sum >= target
+	return sel.individuals[min(i, n-1)], nil
+}
+
+// ------------------------------
+
+// failedSelection is a prepared selection that always returns the preparation error
+type failedSelection struct {
+	err error
+}
+
+func (sel failedSelection) Select(gene.Population) (gene.Individual, error) {
+	return gene.Individual{}, sel.err
+}
@@ -0,0 +1,137 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/sbiemont/galgogene/gene"
+	"github.com/sbiemont/galgogene/random"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// codesCrossOver is prepared with the codes of the population
+type codesCrossOver struct {
+	OnePointCrossOver
+	codes [][]gene.B
+}
+
+func (co codesCrossOver) Prepare(pop gene.Population) CrossOver {
+	for _, ind := range pop.Individuals {
+		co.codes = append(co.codes, ind.Code.Raw)
+	}
+	return co
+}
+
+// codesMutation is prepared with the codes of the population
+type codesMutation struct {
+	UniqueMutation
+	codes [][]gene.B
+}
+
+func (mut codesMutation) Prepare(pop gene.Population) Mutation {
+	for _, ind := range pop.Individuals {
+		mut.codes = append(mut.codes, ind.Code.Raw)
+	}
+	return mut
+}
+
+func TestPreparer(t *testing.T) {
+	Convey("prepare", t, func() {
+		random.Seed(42)
+		pop := gene.Population{
+			Individuals: []gene.Individual{
+				{Fitness: 0.1}, {Fitness: 0.0}, {Fitness: 0.6}, {Fitness: 0.3},
+			},
+		}
+		pop.ComputeTotalFitness()
+
+		Convey("when not a preparer", func() {
+			So(Prepare[Selection](TournamentSelection{Fighters: 2}, pop), ShouldResemble, TournamentSelection{Fighters: 2})
+			So(Prepare[Mutation](UniqueMutation{}, pop), ShouldResemble, UniqueMutation{})
+			So(Prepare[Mutation](nil, pop), ShouldBeNil)
+		})
+
+		Convey("when roulette", func() {
+			sel := Prepare[Selection](RouletteSelection{}, pop)
+			So(sel, ShouldResemble, cumulativeSelection{
+				individuals: pop.Individuals,
+				cumulative:  []float64{0.1, 0.1, 0.7, 1.0},
+			})
+
+			counts := make(map[float64]int)
+			for range 5000 {
+				ind, err := sel.Select(pop)
+				So(err, ShouldBeNil)
+				counts[ind.Fitness]++
+			}
+			So(counts[0.0], ShouldEqual, 0)
+			So(counts[0.1], ShouldBeBetween, 400, 600)
+			So(counts[0.6], ShouldBeBetween, 2850, 3150)
+			So(counts[0.3], ShouldBeBetween, 1350, 1650)
+		})
+
+		Convey("when rank", func() {
+			sel := Prepare[Selection](RankSelection{Pressure: 2}, pop)
+			So(sel, ShouldResemble, cumulativeSelection{
+				individuals: []gene.Individual{{Fitness: 0.0}, {Fitness: 0.1}, {Fitness: 0.3}, {Fitness: 0.6}},
+				cumulative:  []float64{0, 2.0 / 3, 2, 4},
+			})
+
+			_, err := Prepare[Selection](RankSelection{Pressure: 3}, pop).Select(pop)
+			So(err, ShouldBeError, "selection rank: pressure shall be in [1 ; 2]")
+		})
+
+		Convey("when truncation", func() {
+			sel := Prepare[Selection](TruncationSelection{}, pop)
+			So(sel, ShouldResemble, cumulativeSelection{
+				individuals: []gene.Individual{{Fitness: 0.3}, {Fitness: 0.6}},
+				cumulative:  []float64{1, 2},
+			})
+		})
+
+		Convey("when empty population", func() {
+			_, err := Prepare[Selection](RouletteSelection{}, gene.Population{}).Select(gene.Population{})
+			So(err, ShouldBeError, "selection: empty population")
+		})
+
+		Convey("when multi selection", func() {
+			sel := Prepare[Selection](MultiSelection{}.
+				Use(0.5, RouletteSelection{}).
+				Otherwise(TournamentSelection{Fighters: 2}), pop)
+			ms, ok := sel.(multiSelection)
+			So(ok, ShouldBeTrue)
+			So(ms.selections[0].rate, ShouldEqual, 0.5)
+			So(ms.selections[0].sel, ShouldHaveSameTypeAs, cumulativeSelection{})
+			So(ms.deflt, ShouldResemble, TournamentSelection{Fighters: 2})
+		})
+
+		Convey("when multi crossover and multi mutation", func() {
+			co := Prepare[CrossOver](MultiCrossOver{ApplyAll: true}.Use(0.5, OnePointCrossOver{}), pop)
+			So(co, ShouldResemble, MultiCrossOver{ApplyAll: true}.Use(0.5, OnePointCrossOver{}))
+
+			mut := Prepare[Mutation](MultiMutation{}.Use(0.5, UniqueMutation{}), pop)
+			So(mut, ShouldResemble, MultiMutation{}.Use(0.5, UniqueMutation{}))
+		})
+
+		Convey("when genome crossover and genome mutation", func() {
+			newGenome := func(jobs, machines []gene.B) gene.Chromosome {
+				return gene.Genome{
+					Names:       []string{"jobs", "machines"},
+					Chromosomes: []gene.Chromosome{newChromosome(jobs), newChromosome(machines)},
+				}.Chromosome()
+			}
+			gnmPop := gene.Population{
+				Individuals: []gene.Individual{
+					{Code: newGenome([]gene.B{0, 1, 2}, []gene.B{1, 1, 1})},
+					{Code: newGenome([]gene.B{2, 1, 0}, []gene.B{0, 0, 0})},
+				},
+			}
+
+			co := Prepare[CrossOver](GenomeCrossOver{}.Use("machines", codesCrossOver{}), gnmPop)
+			So(co, ShouldResemble, GenomeCrossOver{}.Use("machines", codesCrossOver{codes: [][]gene.B{{1, 1, 1}, {0, 0, 0}}}))
+
+			mut := Prepare[Mutation](GenomeMutation{}.Use("jobs", codesMutation{}), gnmPop)
+			So(mut, ShouldResemble, GenomeMutation{}.Use("jobs", codesMutation{codes: [][]gene.B{{0, 1, 2}, {2, 1, 0}}}))
+			So(gnmPop.Individuals[0].Code.IsGenome(), ShouldBeTrue) // unchanged
+		})
+	})
+}
@@ -0,0 +1,157 @@
+package operator
+
+import (
+	"math"
+	"slices"
+
+	"github.com/sbiemont/galgogene/gene"
+)
+
+// scaling: Goldberg, "Genetic Algorithms in Search, Optimization and Machine Learning" (1989)
+
+// Scaling transforms the raw fitnesses of a population before the selection
+// The selective pressure of the fitness proportionate selections only depends on the scaled fitnesses
+type Scaling interface {
+	// Scale returns the scaled fitness of each individual (in the population order)
+	Scale(pop gene.Population) []float64
+}
+
+// ------------------------------
+
+// LinearScaling scales the fitnesses with f' = a.f + b, keeping the average fitness unchanged
+// and giving Multiple times the average to the best individual.
+// If the worst individual would get a negative fitness, a and b are chosen to give it 0
+type LinearScaling struct {
+	Multiple float64 // Expected number of copies of the best individual, > 1 (default: 2)
+}
+
+func (scl LinearScaling) Scale(pop gene.Population) []float64 {
+	c := getDefault(scl.Multiple, 2)
+	minFit, avgFit, maxFit := fitnessRange(pop)
+
+	// Identity
+	a, b := 1.0, 0.0
+	switch {
+	case maxFit == avgFit || c <= 1:
+	case minFit > (c*avgFit-maxFit)/(c-1): // the worst individual stays >= 0
+		delta := maxFit - avgFit
+		a = (c - 1) * avgFit / delta
+		b = avgFit * (maxFit - c*avgFit) / delta
+	default: // the worst individual is set to 0
+		delta := avgFit - minFit
+		a = avgFit / delta
+		b = -minFit * avgFit / delta
+	}
+	return scale(pop, func(fitness float64) float64 {
+		return a*fitness + b
+	})
+}
+
+// ------------------------------
+
+// SigmaTruncationScaling scales the fitnesses with f' = f - (avg - C.sigma)
+// The individuals worse than C standard deviations below the average get 0
+type SigmaTruncationScaling struct {
+	C float64 // Number of standard deviations (default: 2)
+}
+
+func (scl SigmaTruncationScaling) Scale(pop gene.Population) []float64 {
+	c := getDefault(scl.C, 2)
+	_, avgFit, _ := fitnessRange(pop)
+	var variance float64
+	for _, ind := range pop.Individuals {
+		variance += (ind.Fitness - avgFit) * (ind.Fitness - avgFit)
+	}
+	sigma := math.Sqrt(variance / float64(max(pop.Len(), 1)))
+	return scale(pop, func(fitness float64) float64 {
+		return max(0, fitness-(avgFit-c*sigma))
+	})
+}
+
+// ------------------------------
+
+// PowerScaling scales the fitnesses with f' = f^K (the negative fitnesses get 0)
+// A K greater than 1 increases the selective pressure, a K lower than 1 decreases it
+type PowerScaling struct {
+	K float64 // Exponent (default: 1.005)
+}
+
+func (scl PowerScaling) Scale(pop gene.Population) []float64 {
+	k := getDefault(scl.K, 1.005)
+	return scale(pop, func(fitness float64) float64 {
+		return math.Pow(max(0, fitness), k)
+	})
+}
+
+// ------------------------------
+
+// WindowingScaling scales the fitnesses with f' = f - min, where min is the lowest fitness
+// found during the last Window generations (the worst individual of the window gets 0)
+// It keeps the past minimal fitnesses: use it as a pointer, and do not share it between engines
+type WindowingScaling struct {
+	Window int       // Number of generations (default: 1, the current generation only)
+	mins   []float64 // Minimal fitness of the last generations
+}
+
+func (scl *WindowingScaling) Scale(pop gene.Population) []float64 {
+	minFit, _, _ := fitnessRange(pop)
+	scl.mins = append(scl.mins, minFit)
+	if window := max(getDefault(scl.Window, 1), 1); len(scl.mins) > window {
+		scl.mins = scl.mins[len(scl.mins)-window:]
+	}
+	minWindow := slices.Min(scl.mins)
+	return scale(pop, func(fitness float64) float64 {
+		return fitness - minWindow
+	})
+}
+
+// ------------------------------
+
+// RankScaling replaces each fitness by its normalized rank (rank+1)/n, in ]0 ; 1] (1 for the best individual)
+// The individuals with the same fitness share the same average rank
+type RankScaling struct{}
+
+func (RankScaling) Scale(pop gene.Population) []float64 {
+	n := pop.Len()
+	indexes := rankIndexes(pop)
+	scaled := make([]float64, n)
+	for start := 0; start < n; {
+		// Group the same fitnesses
+		end := start + 1
+		for end < n && pop.Individuals[indexes[end]].Fitness == pop.Individuals[indexes[start]].Fitness {
+			end++
+		}
+		rank := float64(start+end-1) / 2 // average rank of the group
+		for _, index := range indexes[start:end] {
+			scaled[index] = (rank + 1) / float64(n)
+		}
+		start = end
+	}
+	return scaled
+}
+
+// ------------------------------
+
+// scale applies the function on each fitness
+func scale(pop gene.Population, fct func(fitness float64) float64) []float64 {
+	scaled := make([]float64, pop.Len())
+	for i, ind := range pop.Individuals {
+		scaled[i] = fct(ind.Fitness)
+	}
+	return scaled
+}
+
+// fitnessRange returns the minimal, average and maximal fitnesses (0 for an empty population)
+func fitnessRange(pop gene.Population) (float64, float64, float64) {
+	if pop.Len() == 0 {
+		return 0, 0, 0
+	}
+	minFit, maxFit := math.Inf(1), math.Inf(-1)
+	var total float64
+	for _, ind := range pop.Individuals {
+		minFit = min(minFit, ind.Fitness)
+		maxFit = max(maxFit, ind.Fitness)
+		total += ind.Fitness
+	}
+	return minFit, total / float64(pop.Len()), maxFit
+}
@@ -0,0 +1,66 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/sbiemont/galgogene/gene"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScaling(t *testing.T) {
+	newPop := func(fitnesses ...float64) gene.Population {
+		pop := gene.NewPopulation(len(fitnesses))
+		for i, fitness := range fitnesses {
+			pop.Individuals[i].Fitness = fitness
+		}
+		return pop
+	}
+	shouldAlmostResemble := func(actual []float64, expected ...float64) {
+		So(actual, ShouldHaveLength, len(expected))
+		for i := range expected {
+			So(actual[i], ShouldAlmostEqual, expected[i], 1e-6)
+		}
+	}
+
+	Convey("linear scaling", t, func() {
+		Convey("when the worst individual stays positive", func() {
+			shouldAlmostResemble(LinearScaling{Multiple: 1.5}.Scale(newPop(1, 2, 3, 6)), 2, 2.5, 3, 4.5)
+			shouldAlmostResemble(LinearScaling{}.Scale(newPop(0, 0, 0, 4)), 2.0/3, 2.0/3, 2.0/3, 2)
+		})
+
+		Convey("when the worst individual is set to 0", func() {
+			shouldAlmostResemble(LinearScaling{}.Scale(newPop(-3, 1, 2, 4)), 0, 1, 1.25, 1.75)
+		})
+
+		Convey("when identity", func() {
+			So(LinearScaling{}.Scale(newPop(2, 2)), ShouldResemble, []float64{2, 2})
+			So(LinearScaling{Multiple: 0.5}.Scale(newPop(1, 2)), ShouldResemble, []float64{1, 2})
+			So(LinearScaling{}.Scale(newPop()), ShouldBeEmpty)
+		})
+	})
+
+	Convey("sigma truncation scaling", t, func() {
+		// avg: 3, sigma: sqrt(3.5)
+		shouldAlmostResemble(SigmaTruncationScaling{C: 1}.Scale(newPop(1, 2, 3, 6)), 0, 0.8708287, 1.8708287, 4.8708287)
+		shouldAlmostResemble(SigmaTruncationScaling{}.Scale(newPop(1, 1)), 0, 0)
+	})
+
+	Convey("power scaling", t, func() {
+		So(PowerScaling{K: 2}.Scale(newPop(-1, 1, 2, 6)), ShouldResemble, []float64{0, 1, 4, 36})
+		shouldAlmostResemble(PowerScaling{}.Scale(newPop(1, 2)), 1, 2.0069435)
+	})
+
+	Convey("windowing scaling", t, func() {
+		scl := &WindowingScaling{Window: 2}
+		So(scl.Scale(newPop(1, 2, 3, 6)), ShouldResemble, []float64{0, 1, 2, 5})
+		So(scl.Scale(newPop(3, 4)), ShouldResemble, []float64{2, 3}) // min of the window: 1
+		So(scl.Scale(newPop(5, 6)), ShouldResemble, []float64{2, 3}) // min of the window: 3
+
+		So((&WindowingScaling{}).Scale(newPop(5, 6)), ShouldResemble, []float64{0, 1})
+	})
+
+	Convey("rank scaling", t, func() {
+		So(RankScaling{}.Scale(newPop(3, 1, 3, 6)), ShouldResemble, []float64{0.625, 0.25, 0.625, 1})
+		So(RankScaling{}.Scale(newPop()), ShouldBeEmpty)
+	})
+}
@@ -1,7 +1,11 @@
 package operator
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
+	"math"
+	"slices"
 
 	"github.com/sbiemont/galgogene/gene"
 	"github.com/sbiemont/galgogene/random"
@@ -40,32 +44,119 @@ func (RouletteSelection) Select(pop gene.Population) (gene.Individual, error) {
 	return gene.Individual{}, errors.New("selection roulette failed")
 }
 
+// Prepare computes the cumulative sums of the fitnesses once (O(log n) per selection)
+func (RouletteSelection) Prepare(pop gene.Population) Selection {
+	return newCumulativeSelection(pop.Individuals, func(i int) float64 {
+		return pop.Individuals[i].Fitness
+	})
+}
+
 // ------------------------------
 
 // TournamentSelection select the best individual between k individuals
+// Variants:
+//   - without replacement: the fighters are distinct individuals
+//   - probabilistic: the best fighter wins with a probability p, the second one with p(1-p), then p(1-p)^2...
+//   - custom comparator: eg.: to handle constraints or several objectives
 type TournamentSelection struct {
-	Fighters int // Number of fighters
+	Fighters           int                                   // Number of fighters
+	WithoutReplacement bool                                  // Draw distinct fighters (at most the population size)
+	Probability        float64                               // Probability of the best fighter to win, in ]0 ; 1] (default: 1)
+	Less               func(ind1, ind2 gene.Individual) bool // Returns true if ind1 is worse than ind2 (default: lower fitness)
 }
 
 // Select 1 individual between k figthers
 // Choose k individuals from the population and retrieves the best one
 func (st TournamentSelection) Select(pop gene.Population) (gene.Individual, error) {
-	if st.Fighters == 0 {
+	if st.Fighters <= 0 {
 		return gene.Individual{}, errors.New("selection tournament: fighters shall be > 0")
 	}
+	proba := getDefault(st.Probability, 1)
+	if proba <= 0 || proba > 1 {
+		return gene.Individual{}, errors.New("selection tournament: probability shall be in ]0 ; 1]")
+	}
 
 	// Select k indexes from the population
-	indexes := random.OrderedInts(0, len(pop.Individuals), st.Fighters)
+	var indexes []int
+	if st.WithoutReplacement {
+		if st.Fighters > pop.Len() {
+			return gene.Individual{}, fmt.Errorf("selection tournament: fighters (%d) shall be <= population size (%d)", st.Fighters, pop.Len())
+		}
+		indexes = distinctInts(pop.Len(), st.Fighters)
+	} else {
+		indexes = random.OrderedInts(0, len(pop.Individuals), st.Fighters)
+	}
+	less := st.less()
 
 	// Select the best of chosen ones
-	best := &pop.Individuals[indexes[0]]
-	for _, index := range indexes[1:] {
-		current := &pop.Individuals[index]
-		if current.Fitness > best.Fitness {
-			best = current
+	if proba == 1 {
+		best := &pop.Individuals[indexes[0]]
+		for _, index := range indexes[1:] {
+			current := &pop.Individuals[index]
+			if less(*best, *current) {
+				best = current
+			}
+		}
+		return *best, nil
+	}
+
+	// Sort the chosen ones (the best first), each one wins with the given probability
+	fighters := make([]gene.Individual, len(indexes))
+	for i, index := range indexes {
+		fighters[i] = pop.Individuals[index]
+	}
+	slices.SortStableFunc(fighters, func(ind1, ind2 gene.Individual) int {
+		switch {
+		case less(ind2, ind1):
+			return -1
+		case less(ind1, ind2):
+			return 1
+		default:
+			return 0
+		}
+	})
+	for _, fighter := range fighters[:len(fighters)-1] {
+		if random.Peek(proba) {
+			return fighter, nil
 		}
 	}
-	return *best, nil
+	return fighters[len(fighters)-1], nil
+}
+
+// less returns the comparator of the individuals
+func (st TournamentSelection) less() func(ind1, ind2 gene.Individual) bool {
+	if st.Less != nil {
+		return st.Less
+	}
+	return func(ind1, ind2 gene.Individual) bool {
+		return ind1.Fitness < ind2.Fitness
+	}
+}
+
+// ------------------------------
+
+// AdaptiveTournamentSelection adapts the number of fighters to the diversity of the population
+// (see gene.Population.Diversity): Max fighters for a fully diverse population, down to Min fighters
+// when the population converges (a lower selective pressure helps to keep the diversity)
+type AdaptiveTournamentSelection struct {
+	Min        int                 // Min number of fighters (default: 2)
+	Max        int                 // Max number of fighters (default: 5)
+	Tournament TournamentSelection // Options of the tournament (the number of fighters is ignored)
+}
+
+func (sel AdaptiveTournamentSelection) Select(pop gene.Population) (gene.Individual, error) {
+	return sel.Prepare(pop).Select(pop)
+}
+
+// Prepare computes the diversity and the number of fighters of the generation once
+func (sel AdaptiveTournamentSelection) Prepare(pop gene.Population) Selection {
+	minFighters, maxFighters := getDefault(sel.Min, 2), getDefault(sel.Max, 5)
+	if minFighters <= 0 || maxFighters < minFighters {
+		return failedSelection{errors.New("selection adaptive tournament: fighters shall be in 0 < min <= max")}
+	}
+	tournament := sel.Tournament
+	tournament.Fighters = minFighters + int(math.Round(float64(maxFighters-minFighters)*pop.Diversity()))
+	return tournament
 }
 
 // ------------------------------
@@ -79,6 +170,276 @@ func (EliteSelection) Select(pop gene.Population) (gene.Individual, error) {
 
 // ------------------------------
 
+// RankSelection defines a linear rank selection: the probability to be chosen only depends on the rank of the fitness
+// The best individual is Pressure times more likely to be chosen than the average one, the worst 2-Pressure times
+type RankSelection struct {
+	Pressure float64 // Selective pressure in [1 ; 2] (default: 1.5)
+}
+
+func (sel RankSelection) Select(pop gene.Population) (gene.Individual, error) {
+	return sel.Prepare(pop).Select(pop)
+}
+
+// Prepare sorts the population and computes the cumulative weights of the ranks once
+func (sel RankSelection) Prepare(pop gene.Population) Selection {
+	pressure := getDefault(sel.Pressure, 1.5)
+	if pressure < 1 || pressure > 2 {
+		return failedSelection{errors.New("selection rank: pressure shall be in [1 ; 2]")}
+	}
+	n := float64(pop.Len())
+	return rankSelection(pop, func(rank int) float64 {
+		if n == 1 {
+			return 1
+		}
+		return 2 - pressure + 2*(pressure-1)*float64(rank)/(n-1)
+	})
+}
+
+// ------------------------------
+
+// ExponentialRankSelection defines an exponential rank selection:
+// the best individual has a weight of 1, the next one Base, then Base^2...
+type ExponentialRankSelection struct {
+	Base float64 // In ]0 ; 1[ (default: 0.9), a lower base gives a higher selective pressure
+}
+
+func (sel ExponentialRankSelection) Select(pop gene.Population) (gene.Individual, error) {
+	return sel.Prepare(pop).Select(pop)
+}
+
+// Prepare sorts the population and computes the cumulative weights of the ranks once
+func (sel ExponentialRankSelection) Prepare(pop gene.Population) Selection {
+	base := getDefault(sel.Base, 0.9)
+	if base <= 0 || base >= 1 {
+		return failedSelection{errors.New("selection exponential rank: base shall be in ]0 ; 1[")}
+	}
+	n := pop.Len()
+	return rankSelection(pop, func(rank int) float64 {
+		return math.Pow(base, float64(n-1-rank))
+	})
+}
+
+// ------------------------------
+
+// PoolSelection is an optional interface of a selection, used by the engine to select the whole mating pool at once
+type PoolSelection interface {
+	Selection
+	// SelectPool selects exactly n individuals
+	SelectPool(pop gene.Population, n int) ([]gene.Individual, error)
+}
+
+// StochasticUniversalSampling defines a fitness proportionate selection of the whole mating pool
+// using n equally spaced pointers on the roulette (a lower spread than n roulette selections)
+// https://en.wikipedia.org/wiki/Stochastic_universal_sampling
+type StochasticUniversalSampling struct{}
+
+// Select 1 individual using only one pointer (same as the roulette)
+func (StochasticUniversalSampling) Select(pop gene.Population) (gene.Individual, error) {
+	return RouletteSelection{}.Select(pop)
+}
+
+// SelectPool selects n individuals (shuffled, so that the mates are not sorted)
+func (StochasticUniversalSampling) SelectPool(pop gene.Population, n int) ([]gene.Individual, error) {
+	if pop.Len() == 0 {
+		return nil, errors.New("selection sus: empty population")
+	}
+	step := pop.Stats.TotalFitness / float64(n)
+	pointer := random.Percent() * step
+
+	result := make([]gene.Individual, 0, n)
+	var currFitness float64
+	for _, individual := range pop.Individuals {
+		currFitness += individual.Fitness
+		for len(result) < n && pointer <= currFitness {
+			result = append(result, individual)
+			pointer += step
+		}
+	}
+	for len(result) < n { // rounding errors
+		result = append(result, pop.Individuals[pop.Len()-1])
+	}
+	random.Shuffle(result)
+	return result, nil
+}
+
+// ------------------------------
+
+// TruncationSelection selects a random individual among the best ones
+type TruncationSelection struct {
+	Rate float64 // Part of the population that can be chosen, in ]0 ; 1] (default: 0.5)
+}
+
+func (sel TruncationSelection) Select(pop gene.Population) (gene.Individual, error) {
+	return sel.Prepare(pop).Select(pop)
+}
+
+// Prepare sorts the population and keeps the best individuals once
+func (sel TruncationSelection) Prepare(pop gene.Population) Selection {
+	rate := getDefault(sel.Rate, 0.5)
+	if rate <= 0 || rate > 1 {
+		return failedSelection{errors.New("selection truncation: rate shall be in ]0 ; 1]")}
+	}
+	if pop.Len() == 0 {
+		return failedSelection{errors.New("selection truncation: empty population")}
+	}
+	k := max(1, int(math.Ceil(rate*float64(pop.Len()))))
+	best := rankIndividuals(pop)[pop.Len()-k:]
+	return newCumulativeSelection(best, func(int) float64 {
+		return 1
+	})
+}
+
+// ------------------------------
+
+// Schedule gives the temperature of a given generation
+type Schedule func(generation int) float64
+
+// ExponentialSchedule starts at the temperature t0, then multiplies it by alpha at each generation
+func ExponentialSchedule(t0, alpha float64) Schedule {
+	return func(generation int) float64 {
+		return t0 * math.Pow(alpha, float64(generation))
+	}
+}
+
+// BoltzmannSelection defines a selection where the probability to be chosen is proportional to exp(fitness / T)
+// A high temperature T gives an almost uniform selection, a low one favors the best individuals
+type BoltzmannSelection struct {
+	Schedule Schedule // Temperature of each generation (default: 1)
+}
+
+func (sel BoltzmannSelection) Select(pop gene.Population) (gene.Individual, error) {
+	return sel.Prepare(pop).Select(pop)
+}
+
+// Prepare computes the temperature of the generation and the cumulative weights once
+func (sel BoltzmannSelection) Prepare(pop gene.Population) Selection {
+	temperature := 1.0
+	if sel.Schedule != nil {
+		temperature = sel.Schedule(pop.Stats.GenerationNb)
+	}
+	if temperature <= 0 {
+		return failedSelection{errors.New("selection boltzmann: temperature shall be > 0")}
+	}
+	if pop.Len() == 0 {
+		return failedSelection{errors.New("selection boltzmann: empty population")}
+	}
+
+	// Use fitness - max to avoid overflows
+	maxFitness := math.Inf(-1)
+	for _, individual := range pop.Individuals {
+		maxFitness = max(maxFitness, individual.Fitness)
+	}
+	return newCumulativeSelection(pop.Individuals, func(i int) float64 {
+		return math.Exp((pop.Individuals[i].Fitness - maxFitness) / temperature)
+	})
+}
+
+// ------------------------------
+
+// LexicaseSelection filters the population case by case, in a random order, keeping only the individuals
+// with the best score on the current case, then selects a random individual among the remaining ones
+// The individuals shall have case scores (see gene.CaseFitness)
+// https://doi.org/10.1109/TEVC.2014.2362729
+type LexicaseSelection struct{}
+
+func (sel LexicaseSelection) Select(pop gene.Population) (gene.Individual, error) {
+	return sel.Prepare(pop).Select(pop)
+}
+
+// Prepare checks the case scores of the population once
+func (LexicaseSelection) Prepare(pop gene.Population) Selection {
+	return newLexicaseSelection("selection lexicase", pop, func([]float64) float64 {
+		return 0
+	})
+}
+
+// EpsilonLexicaseSelection is a lexicase selection that also keeps the individuals with a score
+// within epsilon of the best score of the current case (suited to continuous scores)
+// https://doi.org/10.1145/2908812.2908898
+type EpsilonLexicaseSelection struct {
+	Epsilon float64 // Tolerance on each case (default: median absolute deviation of the case scores)
+}
+
+func (sel EpsilonLexicaseSelection) Select(pop gene.Population) (gene.Individual, error) {
+	return sel.Prepare(pop).Select(pop)
+}
+
+// Prepare checks the case scores and computes the epsilon of each case once
+func (sel EpsilonLexicaseSelection) Prepare(pop gene.Population) Selection {
+	if sel.Epsilon < 0 {
+		return failedSelection{errors.New("selection epsilon lexicase: epsilon shall be >= 0")}
+	}
+	return newLexicaseSelection("selection epsilon lexicase", pop, func(scores []float64) float64 {
+		if sel.Epsilon > 0 {
+			return sel.Epsilon
+		}
+		return medianAbsoluteDeviation(scores)
+	})
+}
+
+// lexicaseSelection is a prepared lexicase selection
+type lexicaseSelection struct {
+	individuals []gene.Individual
+	epsilons    []float64 // Tolerance of each case
+}
+
+// newLexicaseSelection checks the case scores and computes the epsilon of each case using its scores
+func newLexicaseSelection(name string, pop gene.Population, epsilon func(scores []float64) float64) Selection {
+	if pop.Len() == 0 {
+		return failedSelection{fmt.Errorf("%s: empty population", name)}
+	}
+	nbCases := len(pop.Individuals[0].Cases)
+	if nbCases == 0 {
+		return failedSelection{fmt.Errorf("%s: no case scores found (see gene.CaseFitness)", name)}
+	}
+
+	scores := make([][]float64, nbCases)
+	for i, ind := range pop.Individuals {
+		if len(ind.Cases) != nbCases {
+			return failedSelection{fmt.Errorf("%s: individual #%d has %d cases instead of %d", name, i, len(ind.Cases), nbCases)}
+		}
+		for c, score := range ind.Cases {
+			scores[c] = append(scores[c], score)
+		}
+	}
+
+	epsilons := make([]float64, nbCases)
+	for c := range epsilons {
+		epsilons[c] = epsilon(scores[c])
+	}
+	return lexicaseSelection{
+		individuals: pop.Individuals,
+		epsilons:    epsilons,
+	}
+}
+
+func (sel lexicaseSelection) Select(gene.Population) (gene.Individual, error) {
+	candidates := make([]int, len(sel.individuals))
+	for i := range candidates {
+		candidates[i] = i
+	}
+
+	for _, c := range random.Perm(len(sel.epsilons)) {
+		if len(candidates) == 1 {
+			break
+		}
+		best := math.Inf(-1)
+		for _, i := range candidates {
+			best = max(best, sel.individuals[i].Cases[c])
+		}
+		kept := candidates[:0]
+		for _, i := range candidates {
+			if sel.individuals[i].Cases[c] >= best-sel.epsilons[c] {
+				kept = append(kept, i)
+			}
+		}
+		candidates = kept
+	}
+	return sel.individuals[candidates[random.IntN(len(candidates))]], nil
+}
+
+// ------------------------------
+
 // probaSelection is a probabilistic selection
 type probaSelection struct {
 	rate float64
@@ -120,13 +481,152 @@ func (ms multiSelection) Select(pop gene.Population) (gene.Individual, error) {
 		return gene.Individual{}, errors.New("no default selector defined")
 	}
 
-	// Find for first selector to be used
-	for _, proba := range ms.selections {
+	return ms.at(ms.pick()).Select(pop)
+}
+
+// SelectPool selects n individuals: each one is assigned to a randomly chosen selection (like Select),
+// then each selection selects all its individuals (at once for a pool selection). The pool is shuffled
+func (ms multiSelection) SelectPool(pop gene.Population, n int) ([]gene.Individual, error) {
+	if ms.deflt == nil {
+		return nil, errors.New("no default selector defined")
+	}
+
+	counts := make([]int, len(ms.selections)+1)
+	for range n {
+		counts[ms.pick()]++
+	}
+	prepared := ms.Prepare(pop).(multiSelection)
+	result := make([]gene.Individual, 0, n)
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		individuals, err := selectN(prepared.at(i), pop, count)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, individuals...)
+	}
+	random.Shuffle(result)
+	return result, nil
+}
+
+// pick randomly chooses the first selection to be used (the index of the default selection if none)
+func (ms multiSelection) pick() int {
+	for i, proba := range ms.selections {
 		if random.Peek(proba.rate) {
-			return proba.sel.Select(pop)
+			return i
+		}
+	}
+	return len(ms.selections)
+}
+
+// at returns the selection #i (the default selection after the last one)
+func (ms multiSelection) at(i int) Selection {
+	if i < len(ms.selections) {
+		return ms.selections[i].sel
+	}
+	return ms.deflt
+}
+
+// Prepare all selections for the given population
+func (ms multiSelection) Prepare(pop gene.Population) Selection {
+	selections := make([]probaSelection, len(ms.selections))
+	for i, proba := range ms.selections {
+		selections[i] = probaSelection{
+			rate: proba.rate,
+			sel:  Prepare(proba.sel, pop),
+		}
+	}
+	return multiSelection{
+		selections: selections,
+		deflt:      Prepare(ms.deflt, pop),
+	}
+}
+
+// ------------------------------
+
+// selectN selects n individuals at once using a pool selection, one by one otherwise
+func selectN(sel Selection, pop gene.Population, n int) ([]gene.Individual, error) {
+	if pool, ok := sel.(PoolSelection); ok {
+		return pool.SelectPool(pop, n)
+	}
+	result := make([]gene.Individual, n)
+	for i := range result {
+		ind, err := sel.Select(pop)
+		if err != nil {
+			return nil, err
 		}
+		result[i] = ind
+	}
+	return result, nil
+}
+
+// rankIndexes returns the indexes of the individuals sorted by fitness (the worst first)
+func rankIndexes(pop gene.Population) []int {
+	indexes := make([]int, pop.Len())
+	for i := range indexes {
+		indexes[i] = i
+	}
+	slices.SortStableFunc(indexes, func(i, j int) int {
+		return cmp.Compare(pop.Individuals[i].Fitness, pop.Individuals[j].Fitness)
+	})
+	return indexes
+}
+
+// rankIndividuals returns the individuals sorted by fitness (the worst first)
+func rankIndividuals(pop gene.Population) []gene.Individual {
+	indexes := rankIndexes(pop)
+	individuals := make([]gene.Individual, len(indexes))
+	for rank, index := range indexes {
+		individuals[rank] = pop.Individuals[index]
+	}
+	return individuals
+}
+
+// rankSelection prepares a selection with a probability proportional to the weight of the rank (0 for the worst)
+func rankSelection(pop gene.Population, weight func(rank int) float64) Selection {
+	if pop.Len() == 0 {
+		return failedSelection{errors.New("selection rank: empty population")}
+	}
+	return newCumulativeSelection(rankIndividuals(pop), weight)
+}
+
+// median returns the median of the values (0 if empty)
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
 	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
 
-	// Use default selector
-	return ms.deflt.Select(pop)
+// medianAbsoluteDeviation returns the median of the absolute deviations from the median
+func medianAbsoluteDeviation(values []float64) float64 {
+	m := median(values)
+	deviations := make([]float64, len(values))
+	for i, value := range values {
+		deviations[i] = math.Abs(value - m)
+	}
+	return median(deviations)
+}
+
+// distinctInts returns k distinct random values in [0 ; n[ (Floyd's algorithm, k <= n)
+func distinctInts(n, k int) []int {
+	result := make([]int, 0, k)
+	chosen := make(map[int]struct{}, k)
+	for j := n - k; j < n; j++ {
+		value := random.IntN(j + 1)
+		if _, found := chosen[value]; found {
+			value = j
+		}
+		chosen[value] = struct{}{}
+		result = append(result, value)
+	}
+	return result
 }
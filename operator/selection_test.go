@@ -1,6 +1,7 @@
 package operator
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/sbiemont/galgogene/gene"
@@ -78,6 +79,69 @@ func TestSelection(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(ind.Fitness, ShouldEqual, 0.6)
 			})
+
+			draw := func(sel Selection) map[float64]int {
+				counts := make(map[float64]int)
+				for range 4000 {
+					ind, err := sel.Select(pop)
+					So(err, ShouldBeNil)
+					counts[ind.Fitness]++
+				}
+				return counts
+			}
+
+			Convey("when without replacement", func() {
+				random.Seed(3)
+				So(draw(TournamentSelection{Fighters: 4, WithoutReplacement: true}), ShouldResemble, map[float64]int{0.9: 4000})
+
+				_, err := TournamentSelection{Fighters: 5, WithoutReplacement: true}.Select(pop)
+				So(err, ShouldBeError, "selection tournament: fighters (5) shall be <= population size (4)")
+			})
+
+			Convey("when probabilistic", func() {
+				random.Seed(3)
+				// Sorted fighters win with 1/2, 1/4, 1/8 and the remaining 1/8
+				counts := draw(TournamentSelection{Fighters: 4, WithoutReplacement: true, Probability: 0.5})
+				So(counts[0.9], ShouldBeBetween, 1850, 2150)
+				So(counts[0.6], ShouldBeBetween, 880, 1120)
+				So(counts[0.5], ShouldBeBetween, 400, 600)
+				So(counts[0.1], ShouldBeBetween, 400, 600)
+
+				_, err := TournamentSelection{Fighters: 2, Probability: 2}.Select(pop)
+				So(err, ShouldBeError, "selection tournament: probability shall be in ]0 ; 1]")
+			})
+
+			Convey("when custom comparator", func() {
+				random.Seed(3)
+				worst := func(ind1, ind2 gene.Individual) bool {
+					return ind1.Fitness > ind2.Fitness
+				}
+				So(draw(TournamentSelection{Fighters: 4, WithoutReplacement: true, Less: worst}), ShouldResemble, map[float64]int{0.1: 4000})
+			})
+
+			Convey("when adaptive", func() {
+				// Same chromosomes: diversity = 1/4
+				sel := AdaptiveTournamentSelection{Tournament: TournamentSelection{WithoutReplacement: true}}
+				So(sel.Prepare(pop), ShouldResemble, TournamentSelection{Fighters: 3, WithoutReplacement: true})
+
+				// Different chromosomes: diversity = 1
+				for i := range pop.Individuals {
+					pop.Individuals[i].Code = gene.NewChromosome(1, 4)
+					pop.Individuals[i].Code.Raw[0] = gene.B(i)
+				}
+				So(AdaptiveTournamentSelection{Max: 4}.Prepare(pop), ShouldResemble, TournamentSelection{Fighters: 4})
+				So(draw(AdaptiveTournamentSelection{Min: 4, Max: 4, Tournament: TournamentSelection{WithoutReplacement: true}}), ShouldResemble, map[float64]int{0.9: 4000})
+
+				_, err := AdaptiveTournamentSelection{Min: 3, Max: 2}.Select(pop)
+				So(err, ShouldBeError, "selection adaptive tournament: fighters shall be in 0 < min <= max")
+			})
+
+			Convey("when distinct ints", func() {
+				values := distinctInts(5, 5)
+				slices.Sort(values)
+				So(values, ShouldResemble, []int{0, 1, 2, 3, 4})
+				So(distinctInts(10, 3), ShouldHaveLength, 3)
+			})
 		})
 
 		Convey("when multi selection", func() {
@@ -103,4 +167,179 @@ func TestSelection(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("rank based selections", t, func() {
+		random.Seed(42)
+		pop := gene.Population{
+			Individuals: []gene.Individual{
+				{Fitness: 0.9}, {Fitness: 0.1}, {Fitness: 0.5}, {Fitness: 0.6},
+			},
+		}
+		draw := func(sel Selection) map[float64]int {
+			counts := make(map[float64]int)
+			for range 4000 {
+				ind, err := sel.Select(pop)
+				So(err, ShouldBeNil)
+				counts[ind.Fitness]++
+			}
+			return counts
+		}
+
+		So(rankIndexes(pop), ShouldResemble, []int{1, 2, 3, 0})
+
+		Convey("when linear rank", func() {
+			// Weights (worst first): 0.5 0.83 1.17 1.5
+			counts := draw(RankSelection{})
+			So(counts[0.1], ShouldBeBetween, 400, 600)
+			So(counts[0.9], ShouldBeBetween, 1350, 1650)
+
+			// No pressure: uniform
+			counts = draw(RankSelection{Pressure: 1})
+			So(counts[0.1], ShouldBeBetween, 850, 1150)
+
+			_, err := RankSelection{Pressure: 3}.Select(pop)
+			So(err, ShouldBeError, "selection rank: pressure shall be in [1 ; 2]")
+		})
+
+		Convey("when exponential rank", func() {
+			// Weights (best first): 1 0.5 0.25 0.125
+			counts := draw(ExponentialRankSelection{Base: 0.5})
+			So(counts[0.9], ShouldBeBetween, 2000, 2270)
+			So(counts[0.1], ShouldBeBetween, 170, 370)
+
+			_, err := ExponentialRankSelection{Base: 1}.Select(pop)
+			So(err, ShouldBeError, "selection exponential rank: base shall be in ]0 ; 1[")
+		})
+
+		Convey("when truncation", func() {
+			counts := draw(TruncationSelection{})
+			So(counts, ShouldHaveLength, 2)
+			So(counts[0.9]+counts[0.6], ShouldEqual, 4000)
+			So(draw(TruncationSelection{Rate: 0.1}), ShouldResemble, map[float64]int{0.9: 4000})
+
+			_, err := TruncationSelection{Rate: 2}.Select(pop)
+			So(err, ShouldBeError, "selection truncation: rate shall be in ]0 ; 1]")
+		})
+
+		Convey("when boltzmann", func() {
+			So(draw(BoltzmannSelection{Schedule: ExponentialSchedule(0.001, 1)}), ShouldResemble, map[float64]int{0.9: 4000})
+			counts := draw(BoltzmannSelection{Schedule: ExponentialSchedule(1000, 1)})
+			So(counts[0.1], ShouldBeBetween, 850, 1150)
+
+			pop.Stats.GenerationNb = 2
+			So(ExponentialSchedule(10, 0.5)(2), ShouldEqual, 2.5)
+			_, err := BoltzmannSelection{Schedule: func(int) float64 { return 0 }}.Select(pop)
+			So(err, ShouldBeError, "selection boltzmann: temperature shall be > 0")
+		})
+
+		Convey("when empty population", func() {
+			for _, sel := range []Selection{RankSelection{}, ExponentialRankSelection{}, TruncationSelection{}, BoltzmannSelection{}} {
+				_, err := sel.Select(gene.Population{})
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+
+	Convey("stochastic universal sampling", t, func() {
+		pop := gene.Population{
+			Individuals: []gene.Individual{
+				{Fitness: 1}, {Fitness: 1}, {Fitness: 2}, {Fitness: 0},
+			},
+			Stats: gene.PopulationStats{TotalFitness: 4},
+		}
+		for range 20 {
+			pool, err := StochasticUniversalSampling{}.SelectPool(pop, 8)
+			So(err, ShouldBeNil)
+			counts := make(map[float64]int)
+			for _, ind := range pool {
+				counts[ind.Fitness]++
+			}
+			So(counts, ShouldResemble, map[float64]int{1: 4, 2: 4})
+		}
+
+		ind, err := StochasticUniversalSampling{}.Select(pop)
+		So(err, ShouldBeNil)
+		So(ind.Fitness, ShouldBeIn, []float64{1, 2})
+
+		_, err = StochasticUniversalSampling{}.SelectPool(gene.Population{}, 2)
+		So(err, ShouldBeError, "selection sus: empty population")
+
+		Convey("when in a multi selection", func() {
+			pop.ComputeTotalFitness()
+			var sel Selection = MultiSelection{}.Use(0, EliteSelection{}).Otherwise(StochasticUniversalSampling{})
+			multi, ok := sel.(PoolSelection)
+			So(ok, ShouldBeTrue)
+			pool, err := multi.SelectPool(pop, 8)
+			So(err, ShouldBeNil)
+			counts := make(map[float64]int)
+			for _, ind := range pool {
+				counts[ind.Fitness]++
+			}
+			So(counts, ShouldResemble, map[float64]int{1: 4, 2: 4})
+
+			pool, err = MultiSelection{}.Use(1, EliteSelection{}).Otherwise(StochasticUniversalSampling{}).SelectPool(pop, 3)
+			So(err, ShouldBeNil)
+			So(pool, ShouldResemble, []gene.Individual{{Fitness: 2}, {Fitness: 2}, {Fitness: 2}})
+
+			_, err = multiSelection{}.SelectPool(pop, 2)
+			So(err, ShouldBeError, "no default selector defined")
+		})
+	})
+
+	Convey("lexicase selections", t, func() {
+		random.Seed(42)
+		// The fitness identifies the individual: 3 specialists and 1 generalist
+		pop := gene.Population{
+			Individuals: []gene.Individual{
+				{Fitness: 1, Cases: []float64{1, 0, 0}},
+				{Fitness: 2, Cases: []float64{0, 1, 0}},
+				{Fitness: 3, Cases: []float64{0, 0, 1}},
+				{Fitness: 4, Cases: []float64{0.9, 0.9, 0.9}},
+			},
+		}
+		draw := func(sel Selection) map[float64]int {
+			counts := make(map[float64]int)
+			for range 3000 {
+				ind, err := sel.Select(pop)
+				So(err, ShouldBeNil)
+				counts[ind.Fitness]++
+			}
+			return counts
+		}
+
+		Convey("when lexicase", func() {
+			counts := draw(LexicaseSelection{})
+			So(counts, ShouldHaveLength, 3)
+			for _, fitness := range []float64{1, 2, 3} {
+				So(counts[fitness], ShouldBeBetween, 850, 1150)
+			}
+		})
+
+		Convey("when epsilon lexicase", func() {
+			So(draw(EpsilonLexicaseSelection{Epsilon: 0.2}), ShouldResemble, map[float64]int{4: 3000})
+			So(draw(EpsilonLexicaseSelection{}), ShouldResemble, map[float64]int{4: 3000}) // MAD = 0.45
+
+			_, err := EpsilonLexicaseSelection{Epsilon: -1}.Select(pop)
+			So(err, ShouldBeError, "selection epsilon lexicase: epsilon shall be >= 0")
+		})
+
+		Convey("when invalid cases", func() {
+			_, err := LexicaseSelection{}.Select(gene.Population{})
+			So(err, ShouldBeError, "selection lexicase: empty population")
+
+			_, err = LexicaseSelection{}.Select(gene.Population{Individuals: []gene.Individual{{Fitness: 1}}})
+			So(err, ShouldBeError, "selection lexicase: no case scores found (see gene.CaseFitness)")
+
+			pop.Individuals[2].Cases = []float64{1}
+			_, err = EpsilonLexicaseSelection{}.Select(pop)
+			So(err, ShouldBeError, "selection epsilon lexicase: individual #2 has 1 cases instead of 3")
+		})
+
+		Convey("when median absolute deviation", func() {
+			So(median(nil), ShouldEqual, 0)
+			So(median([]float64{3, 1, 2}), ShouldEqual, 2)
+			So(median([]float64{4, 1, 3, 2}), ShouldEqual, 2.5)
+			So(medianAbsoluteDeviation([]float64{1, 0, 0, 0.9}), ShouldAlmostEqual, 0.45)
+		})
+	})
 }
@@ -1,6 +1,10 @@
 package operator
 
 import (
+	"cmp"
+	"math"
+	"slices"
+
 	"github.com/sbiemont/galgogene/gene"
 	"github.com/sbiemont/galgogene/random"
 )
@@ -11,10 +15,26 @@ type Survivor interface {
 	Survive(parents gene.Population, offsprings gene.Population) gene.Population
 }
 
+// EvaluatingSurvivor is an optional interface of a survivor building new individuals (eg.: UniqueSurvivor)
+// The engine gives the evaluator of the new individuals (fitness and case scores) before each survival
+type EvaluatingSurvivor interface {
+	Survivor
+	// WithEvaluator returns the survivor using the given evaluator
+	WithEvaluator(evaluator gene.Evaluator) Survivor
+}
+
+// WithEvaluator returns the survivor using the given evaluator (or the survivor itself if it is not an EvaluatingSurvivor)
+func WithEvaluator(svr Survivor, evaluator gene.Evaluator) Survivor {
+	if evs, ok := svr.(EvaluatingSurvivor); ok {
+		return evs.WithEvaluator(evaluator)
+	}
+	return svr
+}
+
 // mergePopulations creates a new population with all individuals of both populations but no stats
 func mergePopulations(pop1, pop2 gene.Population) gene.Population {
 	return gene.Population{
-		Individuals: append(pop1.Individuals, pop2.Individuals...),
+		Individuals: slices.Concat(pop1.Individuals, pop2.Individuals),
 	}
 }
 
@@ -53,6 +73,200 @@ func (svr RandomSurvivor) Survive(parents gene.Population, offsprings gene.Popul
 
 // ------------------------------
 
+// CommaSurvivor is the (μ,λ) strategy: it selects the elite from the children population only
+// (the parents are discarded, unless there are not enough children: the best parents complete the population)
+type CommaSurvivor struct{}
+
+func (svr CommaSurvivor) Survive(parents gene.Population, offsprings gene.Population) gene.Population {
+	survivors := gene.Population{Individuals: slices.Clone(offsprings.Individuals)}
+	survivors.SortByFitness()
+	if missing := parents.Len() - survivors.Len(); missing > 0 {
+		best := gene.Population{Individuals: slices.Clone(parents.Individuals)}
+		best.SortByFitness()
+		survivors.Individuals = append(survivors.Individuals, best.Individuals[:missing]...)
+	}
+	return survivors.First(parents.Len())
+}
+
+// ------------------------------
+
+// PlusSurvivor is the (μ+λ) strategy: it selects the elite from the parents + children population,
+// the individuals older than MaxAge generations (see Individual.Rank) are only chosen if there are not enough younger ones
+type PlusSurvivor struct {
+	MaxAge int // Max number of generations an individual survives (0: no limit, same as EliteSurvivor)
+}
+
+func (svr PlusSurvivor) Survive(parents gene.Population, offsprings gene.Population) gene.Population {
+	survivors := mergePopulations(parents, offsprings)
+	survivors.SortByFitness()
+	if svr.MaxAge > 0 {
+		// Move too old individuals at the end (keeping the fitness order)
+		slices.SortStableFunc(survivors.Individuals, func(ind1, ind2 gene.Individual) int {
+			return cmp.Compare(min(ind1.Rank/svr.MaxAge, 1), min(ind2.Rank/svr.MaxAge, 1))
+		})
+	}
+	return survivors.First(parents.Len())
+}
+
+// ------------------------------
+
+// SelectionSurvivor uses a selection to choose each survivor from the parents + children population
+// (an individual may be chosen several times). If the selection fails, the elite is kept
+type SelectionSurvivor struct {
+	Selection Selection
+}
+
+func (svr SelectionSurvivor) Survive(parents gene.Population, offsprings gene.Population) gene.Population {
+	candidates := mergePopulations(parents, offsprings)
+	if candidates.Len() == 0 {
+		return candidates
+	}
+	candidates.ComputeTotalFitness()
+	selection := Prepare(svr.Selection, candidates)
+
+	survivors := gene.NewPopulation(parents.Len())
+	for i := range survivors.Individuals {
+		ind, err := selection.Select(candidates)
+		if err != nil {
+			return EliteSurvivor{}.Survive(parents, offsprings)
+		}
+		survivors.Individuals[i] = ind
+	}
+	return survivors
+}
+
+// ------------------------------
+
+// UniqueSurvivor removes the duplicated chromosomes from the parents + children population before applying the survivor
+// (the best individual of duplicates is kept, the parents first). So that the survivor still fills the new population,
+// each duplicate (parent or child) is replaced by a new individual: a fresh one (if an initializer is set)
+// or a mutated copy (if a mutation is set). Otherwise, or if no unique chromosome is found after a few attempts,
+// the duplicate is kept. The new individuals are evaluated by the engine (see EvaluatingSurvivor)
+type UniqueSurvivor struct {
+	Survivor    Survivor         // Survivor applied on the unique individuals (default: EliteSurvivor)
+	Distance    int              // Chromosomes within this distance are duplicates (default: 0, identical chromosomes)
+	Initializer gene.Initializer // Initializer of the new individuals (optional)
+	Size        int              // Chromosome size given to the initializer (default: 0, the duplicate size), other sizes are not kept
+	Mutation    Mutation         // Mutation of the duplicates, used if no initializer is set (optional)
+	evaluator   gene.Evaluator   // Evaluator of the new individuals (given by the engine)
+}
+
+// uniqueAttempts is the max number of new chromosomes built to replace a duplicate
+const uniqueAttempts = 10
+
+func (svr UniqueSurvivor) Survive(parents gene.Population, offsprings gene.Population) gene.Population {
+	survivor := svr.Survivor
+	if survivor == nil {
+		survivor = EliteSurvivor{}
+	}
+
+	// Keep the unique individuals first, then replace the duplicates
+	flt := uniqueFilter{distance: svr.Distance, keys: make(map[string]struct{})}
+	uniqueParents, dupParents := flt.split(parents)
+	uniqueOffsprings, dupOffsprings := flt.split(offsprings)
+	for _, ind := range dupParents {
+		uniqueParents.Individuals = append(uniqueParents.Individuals, svr.replace(&flt, ind))
+	}
+	for _, ind := range dupOffsprings {
+		uniqueOffsprings.Individuals = append(uniqueOffsprings.Individuals, svr.replace(&flt, ind))
+	}
+	return survivor.Survive(uniqueParents, uniqueOffsprings)
+}
+
+// WithEvaluator gives the evaluator of the new individuals to the survivor (and to the wrapped survivor)
+func (svr UniqueSurvivor) WithEvaluator(evaluator gene.Evaluator) Survivor {
+	if svr.Survivor != nil {
+		svr.Survivor = WithEvaluator(svr.Survivor, evaluator)
+	}
+	svr.evaluator = evaluator
+	return svr
+}
+
+// replace the duplicated individual by a new unique one (or keep it if not possible)
+func (svr UniqueSurvivor) replace(flt *uniqueFilter, duplicate gene.Individual) gene.Individual {
+	if svr.evaluator == nil {
+		return duplicate
+	}
+	size := svr.Size
+	if size <= 0 {
+		size = duplicate.Code.Len()
+	}
+	for range uniqueAttempts {
+		var chrm gene.Chromosome
+		switch {
+		case svr.Initializer != nil:
+			var err error
+			if chrm, err = svr.Initializer.Init(size); err != nil {
+				return duplicate
+			}
+			if chrm.Len() != size {
+				continue
+			}
+		case svr.Mutation != nil:
+			chrm = svr.Mutation.Mutate(duplicate.Code)
+		default:
+			return duplicate
+		}
+		if flt.add(chrm) {
+			ind := gene.NewIndividual(chrm, 0)
+			ind.Fitness, ind.Cases = svr.evaluator(chrm)
+			ind.ScaledFitness = ind.Fitness
+			return ind
+		}
+	}
+	return duplicate
+}
+
+// uniqueFilter keeps track of the unique chromosomes
+type uniqueFilter struct {
+	distance int
+	keys     map[string]struct{} // Identical chromosomes
+	kept     []gene.Chromosome   // Chromosomes within a distance
+}
+
+// add the chromosome if it is unique, returns false if it is a duplicate
+func (flt *uniqueFilter) add(chrm gene.Chromosome) bool {
+	if flt.distance <= 0 {
+		key := chrm.String()
+		if _, found := flt.keys[key]; found {
+			return false
+		}
+		flt.keys[key] = struct{}{}
+		return true
+	}
+
+	for _, other := range flt.kept {
+		if other.Len() == chrm.Len() && other.Distance(chrm) <= flt.distance {
+			return false
+		}
+	}
+	flt.kept = append(flt.kept, chrm)
+	return true
+}
+
+// split the individuals (the best first) into a population of unique individuals and a list of duplicates
+func (flt *uniqueFilter) split(pop gene.Population) (gene.Population, []gene.Individual) {
+	unique := gene.Population{Individuals: make([]gene.Individual, 0, pop.Len())}
+	var duplicates []gene.Individual
+	for _, ind := range sortedIndividuals(pop) {
+		if flt.add(ind.Code) {
+			unique.Individuals = append(unique.Individuals, ind)
+		} else {
+			duplicates = append(duplicates, ind)
+		}
+	}
+	return unique, duplicates
+}
+
+// sortedIndividuals returns a copy of the individuals, the best first
+func sortedIndividuals(pop gene.Population) []gene.Individual {
+	sorted := gene.Population{Individuals: slices.Clone(pop.Individuals)}
+	sorted.SortByFitness()
+	return sorted.Individuals
+}
+
+// ------------------------------
+
 type probaSurvivor struct {
 	rate     float64
 	survivor Survivor
@@ -95,3 +309,87 @@ func (svr multiSurvivor) Survive(parents gene.Population, offsprings gene.Popula
 	// Otherwise, use default survivor
 	return svr.deflt.Survive(parents, offsprings)
 }
+
+// WithEvaluator gives the evaluator to all survivors
+func (svr multiSurvivor) WithEvaluator(evaluator gene.Evaluator) Survivor {
+	survivors := make([]probaSurvivor, len(svr.survivors))
+	for i, proba := range svr.survivors {
+		survivors[i] = probaSurvivor{
+			rate:     proba.rate,
+			survivor: WithEvaluator(proba.survivor, evaluator),
+		}
+	}
+	return multiSurvivor{
+		survivors: survivors,
+		deflt:     WithEvaluator(svr.deflt, evaluator),
+	}
+}
+
+// ------------------------------
+
+type quotaSurvivor struct {
+	quota    float64
+	survivor Survivor
+}
+
+// QuotaSurvivor defines a list of surviving actions, each one fills a fixed part of the next population
+// eg.: 10% elite, 60% tournament and the remaining 30% random
+type QuotaSurvivor []quotaSurvivor
+
+// Use the survivor to fill the given part (in [0 ; 1]) of the next population
+func (svr QuotaSurvivor) Use(quota float64, survivor Survivor) QuotaSurvivor {
+	return append(svr, quotaSurvivor{
+		quota:    quota,
+		survivor: survivor,
+	})
+}
+
+// Otherwise defines the survivor used to fill the remaining part of the next population
+func (svr QuotaSurvivor) Otherwise(survivor Survivor) quotaSurvivors {
+	return quotaSurvivors{
+		survivors: svr,
+		deflt:     survivor,
+	}
+}
+
+// quotaSurvivors defines a list of surviving actions ending with a default one
+type quotaSurvivors struct {
+	survivors []quotaSurvivor
+	deflt     Survivor
+}
+
+// Survive applies all survivors and keeps the first individuals of each result
+// The survivors are independent: an individual may be chosen by several survivors
+func (svr quotaSurvivors) Survive(parents gene.Population, offsprings gene.Population) gene.Population {
+	size := parents.Len()
+	survivors := gene.Population{Individuals: make([]gene.Individual, 0, size)}
+	for _, quota := range svr.survivors {
+		k := min(int(math.Round(quota.quota*float64(size))), size-survivors.Len())
+		if k > 0 {
+			res := quota.survivor.Survive(parents, offsprings)
+			survivors.Individuals = append(survivors.Individuals, res.Individuals[:min(k, res.Len())]...)
+		}
+	}
+
+	// Fill the remaining part
+	if k := size - survivors.Len(); k > 0 {
+		res := svr.deflt.Survive(parents, offsprings)
+		survivors.Individuals = append(survivors.Individuals, res.Individuals[:min(k, res.Len())]...)
+	}
+	return survivors
+}
+
+// WithEvaluator gives the evaluator to all survivors
+func (svr quotaSurvivors) WithEvaluator(evaluator gene.Evaluator) Survivor {
+	survivors := make([]quotaSurvivor, len(svr.survivors))
+	for i, quota := range svr.survivors {
+		survivors[i] = quotaSurvivor{
+			quota:    quota.quota,
+			survivor: WithEvaluator(quota.survivor, evaluator),
+		}
+	}
+	return quotaSurvivors{
+		survivors: survivors,
+		deflt:     WithEvaluator(svr.deflt, evaluator),
+	}
+}
@@ -84,6 +84,209 @@ func TestSurvivor(t *testing.T) {
 			})
 		})
 
+		Convey("when comma", func() {
+			p1 := pop1()
+			p2 := pop2()
+			res := CommaSurvivor{}.Survive(p2, p1)
+			So(p1, ShouldResemble, pop1()) // pop1 unchanged
+			So(p2, ShouldResemble, pop2()) // pop2 unchanged
+			So(res.Individuals, ShouldResemble, []gene.Individual{
+				{Fitness: 0.9, Rank: 9},
+				{Fitness: 0.6, Rank: 6},
+				{Fitness: 0.5, Rank: 5},
+			})
+
+			// Not enough children: complete with the best parent
+			res = CommaSurvivor{}.Survive(p1, p2)
+			So(p1, ShouldResemble, pop1()) // pop1 unchanged
+			So(res.Individuals, ShouldResemble, []gene.Individual{
+				{Fitness: 0.8, Rank: 8},
+				{Fitness: 0.4, Rank: 4},
+				{Fitness: 0.2, Rank: 2},
+				{Fitness: 0.9, Rank: 9},
+			})
+		})
+
+		Convey("when plus", func() {
+			res := PlusSurvivor{MaxAge: 5}.Survive(pop1(), pop2())
+			So(res.Individuals, ShouldResemble, []gene.Individual{
+				{Fitness: 0.4, Rank: 4},
+				{Fitness: 0.2, Rank: 2},
+				{Fitness: 0.1, Rank: 1},
+				{Fitness: 0.9, Rank: 9}, // too old, but not enough young individuals
+			})
+
+			// No age limit
+			res = PlusSurvivor{}.Survive(pop1(), pop2())
+			So(res, ShouldResemble, EliteSurvivor{}.Survive(pop1(), pop2()))
+		})
+
+		Convey("when selection", func() {
+			p1 := pop1()
+			p2 := pop2()
+			res := SelectionSurvivor{Selection: EliteSelection{}}.Survive(p1, p2)
+			So(p1, ShouldResemble, pop1()) // pop1 unchanged
+			So(p2, ShouldResemble, pop2()) // pop2 unchanged
+			So(res.Individuals, ShouldHaveLength, 4)
+			for _, ind := range res.Individuals {
+				So(ind, ShouldResemble, gene.Individual{Fitness: 0.9, Rank: 9})
+			}
+
+			// Failed selection: keep the elite
+			res = SelectionSurvivor{Selection: TournamentSelection{}}.Survive(pop1(), pop2())
+			So(res, ShouldResemble, EliteSurvivor{}.Survive(pop1(), pop2()))
+		})
+
+		Convey("when quota", func() {
+			p1 := pop1()
+			p2 := pop2()
+			res := QuotaSurvivor{}.
+				Use(0.25, EliteSurvivor{}).
+				Use(0.5, RankSurvivor{}).
+				Otherwise(CommaSurvivor{}).
+				Survive(p1, p2)
+			So(p1, ShouldResemble, pop1()) // pop1 unchanged
+			So(p2, ShouldResemble, pop2()) // pop2 unchanged
+			So(res.Individuals, ShouldResemble, []gene.Individual{
+				{Fitness: 0.9, Rank: 9}, // 25% elite
+				{Fitness: 0.1, Rank: 1}, // 50% rank
+				{Fitness: 0.2, Rank: 2},
+				{Fitness: 0.8, Rank: 8}, // remaining comma
+			})
+
+			// Full population before the default survivor
+			deflt := AppliedSurvivor{}
+			res = QuotaSurvivor{}.
+				Use(0.75, EliteSurvivor{}).
+				Use(0.75, RankSurvivor{}).
+				Otherwise(&deflt).
+				Survive(pop1(), pop2())
+			So(deflt.IsApplied, ShouldBeFalse)
+			So(res.Individuals, ShouldResemble, []gene.Individual{
+				{Fitness: 0.9, Rank: 9},
+				{Fitness: 0.8, Rank: 8},
+				{Fitness: 0.6, Rank: 6},
+				{Fitness: 0.1, Rank: 1},
+			})
+
+			// Parents with a spare capacity (eg.: the first individuals of a population) are not overwritten
+			newPop := func(fitnesses ...float64) gene.Population {
+				pop := gene.NewPopulation(len(fitnesses))
+				for i, fitness := range fitnesses {
+					pop.Individuals[i].Fitness = fitness
+				}
+				return pop
+			}
+			parents := newPop(1, 2, 3, 4, 0, 0, 0, 0).First(4)
+			res = QuotaSurvivor{}.
+				Use(0.5, EliteSurvivor{}).
+				Otherwise(EliteSurvivor{}).
+				Survive(parents, newPop(10, 20, 30, 40))
+			fitnesses := func(pop gene.Population) []float64 {
+				result := make([]float64, pop.Len())
+				for i, ind := range pop.Individuals {
+					result[i] = ind.Fitness
+				}
+				return result
+			}
+			So(fitnesses(res), ShouldResemble, []float64{40, 30, 40, 30})
+			So(fitnesses(parents), ShouldResemble, []float64{1, 2, 3, 4})
+		})
+
+		Convey("when unique", func() {
+			newInd := func(fitness float64, raw ...gene.B) gene.Individual {
+				code := gene.NewChromosome(len(raw), 255)
+				copy(code.Raw, raw)
+				return gene.Individual{Fitness: fitness, ScaledFitness: fitness, Code: code}
+			}
+			codes := func(pop gene.Population) []string {
+				result := make([]string, pop.Len())
+				for i, ind := range pop.Individuals {
+					result[i] = ind.Code.String()
+				}
+				return result
+			}
+			parents := gene.Population{Individuals: []gene.Individual{newInd(0.5, 1, 0), newInd(0.9, 0, 0), newInd(0.9, 0, 0)}}
+			offsprings := gene.Population{Individuals: []gene.Individual{newInd(0.9, 0, 0), newInd(0.7, 1, 1)}}
+
+			Convey("when duplicates are kept", func() {
+				// Without evaluator (outside the engine), the duplicates cannot be replaced
+				res := UniqueSurvivor{Mutation: UniqueMutation{}}.Survive(parents, offsprings)
+				So(codes(res), ShouldResemble, []string{"\x00\x00", "\x00\x00", "\x00\x00"})
+			})
+
+			Convey("when duplicates are replaced", func() {
+				random.Seed(42)
+				var evaluations int
+				evaluator := func(gene.Chromosome) (float64, []float64) {
+					evaluations++
+					return 0.1, []float64{0.1}
+				}
+				svr := WithEvaluator(UniqueSurvivor{
+					Survivor:    CommaSurvivor{},
+					Initializer: gene.RandomInitializer{MaxValue: 255},
+					Size:        2,
+				}, evaluator)
+
+				// The children are kept (the unique one and the new one), completed by the best parent
+				res := svr.Survive(parents, offsprings)
+				So(evaluations, ShouldEqual, 2) // the duplicated parent and the duplicated child
+				So(res.Len(), ShouldEqual, 3)
+				So(len(res.Unique()), ShouldEqual, 3)
+				So(res.Individuals[1].Fitness, ShouldEqual, 0.1)
+				So(res.Individuals[1].Cases, ShouldResemble, []float64{0.1})
+				So(res.Individuals[1].Code.Len(), ShouldEqual, 2)
+
+				// Default size: the duplicate size
+				evaluations = 0
+				svr = WithEvaluator(UniqueSurvivor{Initializer: gene.RandomInitializer{MaxValue: 255}}, evaluator)
+				res = svr.Survive(parents, offsprings)
+				So(evaluations, ShouldEqual, 2)
+				So(len(res.Unique()), ShouldEqual, res.Len())
+				for _, ind := range res.Individuals {
+					So(ind.Code.Len(), ShouldEqual, 2)
+				}
+
+				// Fresh chromosomes of another size: the duplicates are kept
+				evaluations = 0
+				svr = WithEvaluator(UniqueSurvivor{Initializer: gene.VariableInitializer{MinLen: 3, MaxLen: 3, MaxValue: 255}}, evaluator)
+				res = svr.Survive(parents, offsprings)
+				So(evaluations, ShouldEqual, 0)
+				for _, ind := range res.Individuals {
+					So(ind.Code.Len(), ShouldEqual, 2)
+				}
+
+				// Mutated copies
+				svr = WithEvaluator(UniqueSurvivor{Mutation: UniqueMutation{}}, evaluator)
+				res = svr.Survive(parents, offsprings)
+				So(len(res.Unique()), ShouldEqual, 3)
+				So(res.Individuals[0].Code.String(), ShouldEqual, "\x00\x00")
+				So(res.Individuals[1].Fitness, ShouldEqual, 0.7)
+				So(res.Individuals[2].Code.Distance(parents.Individuals[1].Code), ShouldEqual, 1)
+			})
+
+			Convey("when in a multi survivor", func() {
+				svr := WithEvaluator(MultiSurvivor{}.Otherwise(QuotaSurvivor{}.Otherwise(UniqueSurvivor{})),
+					func(gene.Chromosome) (float64, []float64) { return 0, nil })
+				unique := svr.(multiSurvivor).deflt.(quotaSurvivors).deflt.(UniqueSurvivor)
+				So(unique.evaluator, ShouldNotBeNil)
+				So(WithEvaluator(EliteSurvivor{}, nil), ShouldResemble, EliteSurvivor{})
+			})
+
+			Convey("when duplicates within a distance", func() {
+				flt := uniqueFilter{distance: 1}
+				So(flt.add(newInd(0, 0, 0, 0).Code), ShouldBeTrue)
+				So(flt.add(newInd(0, 0, 0, 1).Code), ShouldBeFalse)
+				So(flt.add(newInd(0, 0, 1, 1).Code), ShouldBeTrue)
+				So(flt.add(newInd(0, 1, 1, 1).Code), ShouldBeFalse)
+				So(flt.add(newInd(0, 1, 1).Code), ShouldBeTrue) // other size
+				So(flt.kept, ShouldHaveLength, 3)
+
+				res := UniqueSurvivor{Distance: 1}.Survive(parents, offsprings)
+				So(codes(res), ShouldResemble, []string{"\x00\x00", "\x00\x00", "\x00\x00"}) // without evaluator, the duplicates are kept
+			})
+		})
+
 		Convey("when multi", func() {
 			Convey("when first applied", func() {
 				survivor1 := AppliedSurvivor{}
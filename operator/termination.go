@@ -102,7 +102,7 @@ func condition(cond bool, termination Termination) Termination {
 }
 
 // Helper, get the default value
-func getDefault(value, deflt int) int {
+func getDefault[T int | float64](value, deflt T) T {
 	if value == 0 {
 		return deflt
 	}
@@ -3,22 +3,32 @@ package random
 import (
 	"math/rand/v2"
 	"sort"
+	"sync"
 )
 
 // random: group here all calls to package "math/rand"
-var gen *rand.Rand
+// The generator is shared: all calls are serialized, so that it can be used by concurrent processes
+var (
+	gen   *rand.Rand
+	genMu sync.Mutex
+)
 
 func init() {
 	Seed(rand.Uint64())
 }
 
+// Seed restarts the generator with the given seed (the same seed gives the same sequence of draws)
 func Seed(seed uint64) {
+	genMu.Lock()
+	defer genMu.Unlock()
 	gen = rand.New(rand.NewPCG(42, seed))
 }
 
 // OrderedInts builds an ordered list of k random integers in [min ; max[
 // ex: (1, 2, 2, 9)
 func OrderedInts(min, max, k int) []int {
+	genMu.Lock()
+	defer genMu.Unlock()
 	dm := max - min
 	result := make([]int, k)
 	for i := range k {
@@ -32,6 +42,8 @@ func OrderedInts(min, max, k int) []int {
 
 // UInt64 returns a random uint64
 func Uint64() uint64 {
+	genMu.Lock()
+	defer genMu.Unlock()
 	return gen.Uint64()
 }
 
@@ -42,21 +54,29 @@ func Peek(rate float64) bool {
 
 // Percent returns a random percentage in [0 ; 1[
 func Percent() float64 {
+	genMu.Lock()
+	defer genMu.Unlock()
 	return gen.Float64()
 }
 
 // Perm returns a permutation of n ints
 func Perm(n int) []int {
+	genMu.Lock()
+	defer genMu.Unlock()
 	return gen.Perm(n)
 }
 
 // IntN returns a random int in [0; n[
 func IntN(n int) int {
+	genMu.Lock()
+	defer genMu.Unlock()
 	return gen.IntN(n)
 }
 
 // Shuffle randomizes the order of elements
 func Shuffle[T any](items []T) {
+	genMu.Lock()
+	defer genMu.Unlock()
 	gen.Shuffle(len(items), func(i, j int) {
 		items[i], items[j] = items[j], items[i]
 	})
@@ -2,6 +2,7 @@ package random
 
 import (
 	"sort"
+	"sync"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -17,5 +18,25 @@ func TestRandom(t *testing.T) {
 			})
 			So(result, ShouldResemble, []int{13, 15, 16, 16})
 		})
+
+		Convey("concurrent draws", func() {
+			var wg sync.WaitGroup
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 100 {
+						_ = IntN(10) + len(Perm(3))
+					}
+				}()
+			}
+			wg.Wait()
+
+			// Same seed, same draws
+			Seed(7)
+			first := []uint64{Uint64(), Uint64()}
+			Seed(7)
+			So([]uint64{Uint64(), Uint64()}, ShouldResemble, first)
+		})
 	})
 }